@@ -1,5 +1,7 @@
 package productplan
 
+import "context"
+
 const httpHeaderAuthorization = "Authorization"
 
 // Credentials to be used for authenticating requests
@@ -7,6 +9,31 @@ type Credentials interface {
 	Headers() map[string]string
 }
 
+// ContextCredentials are Credentials that may have to perform requests to build the headers,
+// eg. to obtain an access token. The client calls HeadersWithContext instead of Headers when available.
+type ContextCredentials interface {
+	Credentials
+	HeadersWithContext(ctx context.Context) (map[string]string, error)
+}
+
+// RefreshableCredentials are ContextCredentials whose access token can be refreshed on demand.
+// The client refreshes them, and retries the request once, when the API answers 401 Unauthorized.
+type RefreshableCredentials interface {
+	ContextCredentials
+	Refresh(ctx context.Context) error
+}
+
+// credentialsHeaders returns the authentication headers of credentials.
+func credentialsHeaders(ctx context.Context, credentials Credentials) (map[string]string, error) {
+	switch c := credentials.(type) {
+	case nil:
+		return nil, nil
+	case ContextCredentials:
+		return c.HeadersWithContext(ctx)
+	}
+	return credentials.Headers(), nil
+}
+
 // OAuth token authentication
 type oauthTokenCredentials struct {
 	oauthToken string
@@ -0,0 +1,199 @@
+package productplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+)
+
+// ErrNoParentBar is returned by GetParentBar for a bar that is not the child of a container bar.
+var ErrNoParentBar = errors.New("productplan: bar has no parent bar")
+
+// ListChildBars get the child bars of a container bar
+func (s *BarsService) ListChildBars(bar Bar) (*ListResponse[Bar], error) {
+	return s.ListChildBarsWithContext(context.Background(), bar, nil)
+}
+
+// ListChildBarsWithContext get a page of the child bars of a container bar using the given context.
+// Use options.Cursor to select the page to return.
+func (s *BarsService) ListChildBarsWithContext(ctx context.Context, bar Bar, options *ListOptions) (*ListResponse[Bar], error) {
+	href := bar.ChildBars["href"]
+	if href == "" {
+		href = fmt.Sprintf("/api/bars/%v/child_bars", bar.ID)
+	}
+	return getList[Bar](ctx, s.client, href, options)
+}
+
+// IterChildBars iterates over all the child bars of a container bar, fetching the pages as needed.
+func (s *BarsService) IterChildBars(ctx context.Context, bar Bar, options *ListOptions) *Iterator[Bar] {
+	return newIterator(ctx, func(ctx context.Context, cursor *Cursor) (*ListResponse[Bar], error) {
+		return s.ListChildBarsWithContext(ctx, bar, withCursor(options, cursor))
+	})
+}
+
+// GetParentBar get the container bar of a bar, or ErrNoParentBar
+func (s *BarsService) GetParentBar(bar Bar) (*BarsResponse, error) {
+	return s.GetParentBarWithContext(context.Background(), bar)
+}
+
+// GetParentBarWithContext get the container bar of a bar using the given context
+func (s *BarsService) GetParentBarWithContext(ctx context.Context, bar Bar) (*BarsResponse, error) {
+	href := bar.ParentBar["href"]
+	if href == "" {
+		return nil, ErrNoParentBar
+	}
+	return s.GetBarByHrefWithContext(ctx, href)
+}
+
+// parentBarID returns the ID of the parent of a bar, from its link, or false if it has none.
+func parentBarID(bar Bar) (int, bool) {
+	id := hrefID(bar.ParentBar["href"])
+	return id, id != 0
+}
+
+// hrefID returns the ID at the end of a link, or 0.
+func hrefID(href string) int {
+	id, err := strconv.Atoi(path.Base(href))
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// BarNode is a bar in a BarTree.
+type BarNode struct {
+	Bar      Bar
+	Parent   *BarNode
+	Children []*BarNode
+
+	// Depth is 0 for the root bars, 1 for their children, and so on.
+	Depth int
+}
+
+// BarTree is the container/child hierarchy of the bars of a roadmap.
+type BarTree struct {
+	// Roots are the bars without a parent on the roadmap, in the order they were listed.
+	Roots []*BarNode
+
+	// Cycles lists the IDs of the bars whose parent link would have closed a cycle.
+	// They are attached to the tree as roots instead.
+	Cycles []int
+
+	nodes map[int]*BarNode
+}
+
+// BuildBarTree builds the bar hierarchy from a list of bars, eg. the bars returned by RoadmapsService.GetBars.
+// Bars whose parent is not in the list are roots. Children keep the order of the list.
+func BuildBarTree(bars []Bar) *BarTree {
+	tree := &BarTree{nodes: make(map[int]*BarNode, len(bars))}
+
+	for _, bar := range bars {
+		if _, ok := tree.nodes[bar.ID]; !ok {
+			tree.nodes[bar.ID] = &BarNode{Bar: bar}
+		}
+	}
+
+	seen := make(map[int]bool, len(bars))
+	for _, bar := range bars {
+		if seen[bar.ID] {
+			continue
+		}
+		seen[bar.ID] = true
+		node := tree.nodes[bar.ID]
+
+		parentID, ok := parentBarID(bar)
+		parent := tree.nodes[parentID]
+		if !ok || parent == nil {
+			tree.Roots = append(tree.Roots, node)
+			continue
+		}
+
+		if tree.isAncestor(node, parent) {
+			tree.Cycles = append(tree.Cycles, bar.ID)
+			tree.Roots = append(tree.Roots, node)
+			continue
+		}
+
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+
+	tree.WalkBreadthFirst(func(node *BarNode) bool {
+		if node.Parent != nil {
+			node.Depth = node.Parent.Depth + 1
+		}
+		return true
+	})
+
+	return tree
+}
+
+// isAncestor reports whether node is ancestor of, or is, other.
+func (t *BarTree) isAncestor(node, other *BarNode) bool {
+	for n := other; n != nil; n = n.Parent {
+		if n == node {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the node of a bar, or nil if the bar is not in the tree.
+func (t *BarTree) Get(id int) *BarNode {
+	return t.nodes[id]
+}
+
+// Len returns the number of bars in the tree.
+func (t *BarTree) Len() int {
+	return len(t.nodes)
+}
+
+// WalkDepthFirst calls fn for each bar, each parent before its children.
+// Walking stops when fn returns false. Each bar is visited at most once.
+func (t *BarTree) WalkDepthFirst(fn func(node *BarNode) bool) {
+	visited := make(map[*BarNode]bool, len(t.nodes))
+
+	stack := make([]*BarNode, 0, len(t.Roots))
+	for i := len(t.Roots) - 1; i >= 0; i-- {
+		stack = append(stack, t.Roots[i])
+	}
+
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[node] {
+			continue
+		}
+		visited[node] = true
+
+		if !fn(node) {
+			return
+		}
+		for i := len(node.Children) - 1; i >= 0; i-- {
+			stack = append(stack, node.Children[i])
+		}
+	}
+}
+
+// WalkBreadthFirst calls fn for each bar, level by level from the roots.
+// Walking stops when fn returns false. Each bar is visited at most once.
+func (t *BarTree) WalkBreadthFirst(fn func(node *BarNode) bool) {
+	visited := make(map[*BarNode]bool, len(t.nodes))
+	queue := append([]*BarNode(nil), t.Roots...)
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if visited[node] {
+			continue
+		}
+		visited[node] = true
+
+		if !fn(node) {
+			return
+		}
+		queue = append(queue, node.Children...)
+	}
+}
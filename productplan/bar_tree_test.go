@@ -0,0 +1,139 @@
+package productplan
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBarsService_ListChildBars(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/bars/205414/child_bars", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/roadmaps/get_bars_success.http")
+
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	container := Bar{ID: 205414, BarLinks: BarLinks{ChildBars: map[string]string{"href": "/api/bars/205414/child_bars"}}}
+
+	barsResponse, err := client.Bars.ListChildBars(container)
+	if err != nil {
+		t.Fatalf("Bars.ListChildBars() returned error: %v", err)
+	}
+	if len(barsResponse.Items) == 0 {
+		t.Errorf("Bars.ListChildBars() returned no bars")
+	}
+
+	// without links, the path is built from the ID
+	if _, err := client.Bars.ListChildBars(Bar{ID: 205414}); err != nil {
+		t.Fatalf("Bars.ListChildBars() returned error: %v", err)
+	}
+}
+
+func TestBarsService_GetParentBar(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/bars/205414", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/bars/get_bar_success.http")
+
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	child := Bar{ID: 205500, BarLinks: BarLinks{ParentBar: map[string]string{"href": "/api/bars/205414"}}}
+
+	parent, err := client.Bars.GetParentBar(child)
+	if err != nil {
+		t.Fatalf("Bars.GetParentBar() returned error: %v", err)
+	}
+	if parent.ID != 205414 {
+		t.Errorf("Bars.GetParentBar() returned bar %v, want %v", parent.ID, 205414)
+	}
+
+	if _, err := client.Bars.GetParentBar(parent.Bar); !errors.Is(err, ErrNoParentBar) {
+		t.Errorf("Bars.GetParentBar() error = %v, want %v", err, ErrNoParentBar)
+	}
+}
+
+// treeBar returns a bar with the given parent, 0 for none.
+func treeBar(id, parent int) Bar {
+	bar := Bar{ID: id}
+	if parent != 0 {
+		bar.ParentBar = map[string]string{"href": fmt.Sprintf("/api/bars/%v", parent)}
+	}
+	return bar
+}
+
+func walkIDs(walk func(func(*BarNode) bool)) []int {
+	var ids []int
+	walk(func(node *BarNode) bool {
+		ids = append(ids, node.Bar.ID)
+		return true
+	})
+	return ids
+}
+
+func TestBuildBarTree(t *testing.T) {
+	//   1        5 (parent 9 is on another roadmap)
+	//  / \
+	// 2   3
+	//     |
+	//     4
+	bars := []Bar{treeBar(4, 3), treeBar(1, 0), treeBar(2, 1), treeBar(3, 1), treeBar(5, 9)}
+
+	tree := BuildBarTree(bars)
+
+	if tree.Len() != 5 {
+		t.Errorf("Len() = %v, want %v", tree.Len(), 5)
+	}
+	if got, want := walkIDs(tree.WalkDepthFirst), []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WalkDepthFirst() visited %v, want %v", got, want)
+	}
+	if got, want := walkIDs(tree.WalkBreadthFirst), []int{1, 5, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WalkBreadthFirst() visited %v, want %v", got, want)
+	}
+
+	if node := tree.Get(4); node.Depth != 2 || node.Parent.Bar.ID != 3 {
+		t.Errorf("Get(4) = depth %v, want depth 2 under bar 3", node.Depth)
+	}
+	if len(tree.Cycles) != 0 {
+		t.Errorf("Cycles = %v, want none", tree.Cycles)
+	}
+}
+
+func TestBuildBarTree_Cycle(t *testing.T) {
+	// 1 -> 2 -> 3 -> 1
+	tree := BuildBarTree([]Bar{treeBar(1, 3), treeBar(2, 1), treeBar(3, 2)})
+
+	if got, want := tree.Cycles, []int{3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Cycles = %v, want %v", got, want)
+	}
+	if got, want := walkIDs(tree.WalkDepthFirst), []int{3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WalkDepthFirst() visited %v, want %v", got, want)
+	}
+}
+
+func TestBarTree_WalkStop(t *testing.T) {
+	tree := BuildBarTree([]Bar{treeBar(1, 0), treeBar(2, 1), treeBar(3, 1)})
+
+	var visited []int
+	tree.WalkDepthFirst(func(node *BarNode) bool {
+		visited = append(visited, node.Bar.ID)
+		return node.Bar.ID != 2
+	})
+	if want := []int{1, 2}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("WalkDepthFirst() visited %v, want %v", visited, want)
+	}
+}
@@ -1,6 +1,7 @@
 package productplan
 
 import (
+	"context"
 	"fmt"
 	"io"
 )
@@ -51,6 +52,20 @@ type UpdateBar struct {
 	Fields         map[string]string `json:"fields,omitempty"`
 }
 
+// CreateBar bar attributes to create a bar on a roadmap
+type CreateBar struct {
+	Name           string            `json:"name"`
+	StartDate      string            `json:"start_date,omitempty"`
+	EndDate        string            `json:"end_date,omitempty"`
+	Description    string            `json:"description,omitempty"`
+	StrategicValue string            `json:"strategic_value,omitempty"`
+	Notes          string            `json:"notes,omitempty"`
+	PercentDone    int               `json:"percent_done,omitempty"`
+	Effort         int               `json:"effort,omitempty"`
+	Tags           []string          `json:"tags,omitempty"`
+	Fields         map[string]string `json:"fields,omitempty"`
+}
+
 // BarsResponse represents a response from an API method that returns a bars struct.
 type BarsResponse struct {
 	Response
@@ -58,35 +73,108 @@ type BarsResponse struct {
 }
 
 // ListBars get a list of bars owned by the authenticated user
-func (s *BarsService) ListBars(options *ListOptions) (*[]BarsResponse, error) {
-	path := "/api/bars"
-	var barsResponse *[]BarsResponse
+func (s *BarsService) ListBars(options *ListOptions) (*ListResponse[Bar], error) {
+	return s.ListBarsWithContext(context.Background(), options)
+}
+
+// ListBarsWithContext get a page of bars owned by the authenticated user using the given context.
+// Use options.Cursor to select the page to return.
+func (s *BarsService) ListBarsWithContext(ctx context.Context, options *ListOptions) (*ListResponse[Bar], error) {
+	return getList[Bar](ctx, s.client, "/api/bars", options)
+}
+
+// IterBars iterates over all the bars owned by the authenticated user, fetching the pages as needed.
+func (s *BarsService) IterBars(ctx context.Context, options *ListOptions) *Iterator[Bar] {
+	return newIterator(ctx, func(ctx context.Context, cursor *Cursor) (*ListResponse[Bar], error) {
+		return s.ListBarsWithContext(ctx, withCursor(options, cursor))
+	})
+}
+
+// GetBar bar by ID
+func (s *BarsService) GetBar(id int) (*BarsResponse, error) {
+	return s.GetBarWithContext(context.Background(), id)
+}
+
+// GetBarWithContext bar by ID using the given context
+func (s *BarsService) GetBarWithContext(ctx context.Context, id int) (*BarsResponse, error) {
+	return s.GetBarByHrefWithContext(ctx, fmt.Sprintf("/api/bars/%v", id))
+}
 
-	path, err := addURLQueryOptions(path, options)
+// GetBarByHref bar by the Href returned by the API, eg. /api/bars/205414
+func (s *BarsService) GetBarByHref(href string) (*BarsResponse, error) {
+	return s.GetBarByHrefWithContext(context.Background(), href)
+}
+
+// GetBarByHrefWithContext bar by the Href returned by the API using the given context
+func (s *BarsService) GetBarByHrefWithContext(ctx context.Context, href string) (*BarsResponse, error) {
+	barsResponse := &BarsResponse{}
+
+	resp, err := s.client.get(ctx, href, barsResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = s.client.get(path, &barsResponse)
+	barsResponse.Response = newResponse(resp)
+	return barsResponse, nil
+}
+
+// CreateBar creates a bar on a roadmap, and returns the new bar with its ID and links
+func (s *BarsService) CreateBar(roadmapID int, barAttributes interface{}) (*BarsResponse, error) {
+	return s.CreateBarWithContext(context.Background(), roadmapID, barAttributes)
+}
+
+// CreateBarWithContext creates a bar on a roadmap using the given context
+func (s *BarsService) CreateBarWithContext(ctx context.Context, roadmapID int, barAttributes interface{}) (*BarsResponse, error) {
+	path := fmt.Sprintf("/api/roadmaps/%v/bars", roadmapID)
+	barsResponse := &BarsResponse{}
+
+	resp, err := s.client.post(ctx, path, barAttributes, barsResponse)
 	if err != nil {
 		return nil, err
 	}
 
+	barsResponse.Response = newResponse(resp)
 	return barsResponse, nil
 }
 
 // UpdateBar updates a bar
 func (s *BarsService) UpdateBar(id int, barAttributes interface{}) (*BarsResponse, error) {
+	return s.UpdateBarWithContext(context.Background(), id, barAttributes)
+}
+
+// UpdateBarWithContext updates a bar using the given context
+func (s *BarsService) UpdateBarWithContext(ctx context.Context, id int, barAttributes interface{}) (*BarsResponse, error) {
 	path := fmt.Sprintf("/api/bars/%v", id)
 	barsResponse := &BarsResponse{}
 
-	resp, err := s.client.patch(path, barAttributes, barsResponse)
+	resp, err := s.client.patch(ctx, path, barAttributes, barsResponse)
 
 	// update does not return any response data, so expect the response decode to be EOF
 	if err != nil && err != io.EOF {
 		return nil, err
 	}
 
-	barsResponse.HTTPResponse = resp
+	barsResponse.Response = newResponse(resp)
+	return barsResponse, nil
+}
+
+// DeleteBar deletes a bar
+func (s *BarsService) DeleteBar(id int) (*BarsResponse, error) {
+	return s.DeleteBarWithContext(context.Background(), id)
+}
+
+// DeleteBarWithContext deletes a bar using the given context
+func (s *BarsService) DeleteBarWithContext(ctx context.Context, id int) (*BarsResponse, error) {
+	path := fmt.Sprintf("/api/bars/%v", id)
+	barsResponse := &BarsResponse{}
+
+	resp, err := s.client.delete(ctx, path, barsResponse)
+
+	// delete does not return any response data, so expect the response decode to be EOF
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	barsResponse.Response = newResponse(resp)
 	return barsResponse, nil
 }
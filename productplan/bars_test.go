@@ -1,6 +1,7 @@
 package productplan
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"reflect"
@@ -55,8 +56,8 @@ func TestBarsService_ListBars_WithOptions(t *testing.T) {
 		BarLinks:       barLinks,
 	}
 
-	bar := (*barsResponse)[0]
-	got := bar.Bar
+	bar := barsResponse.Items[0]
+	got := bar
 
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("Bar.ListBars returned GOT: %+v, WANT %+v", got, want)
@@ -92,3 +93,129 @@ func TestBarsService_UpdateBar(t *testing.T) {
 		t.Errorf("barsResponse.HTTPResponse.StatusCode GOT: %+v", barsResponse.HTTPResponse.StatusCode)
 	}
 }
+
+func TestBarsService_GetBar(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/bars/205414", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/bars/get_bar_success.http")
+
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	barsResponse, err := client.Bars.GetBar(205414)
+	if err != nil {
+		t.Fatalf("Bars.GetBar() returned error: %v", err)
+	}
+
+	if barsResponse.ID != 205414 || barsResponse.Name != "APIBar1001" {
+		t.Errorf("Bars.GetBar() returned GOT: %+v", barsResponse.Bar)
+	}
+
+	// the Href of a bar points to the same resource
+	byHref, err := client.Bars.GetBarByHref(barsResponse.Href)
+	if err != nil {
+		t.Fatalf("Bars.GetBarByHref() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(byHref.Bar, barsResponse.Bar) {
+		t.Errorf("Bars.GetBarByHref() returned GOT: %+v, WANT %+v", byHref.Bar, barsResponse.Bar)
+	}
+}
+
+func TestBarsService_CreateBar(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/roadmaps/4946/bars", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/bars/create_bar_success.http")
+
+		testMethod(t, r, "POST")
+		testHeaders(t, r)
+
+		var got map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&got)
+		want := map[string]interface{}{"name": "APIBar1002", "start_date": "2018-01-07", "effort": float64(3)}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Request body GOT: %v, WANT %v", got, want)
+		}
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	barAttributes := CreateBar{
+		Name:      "APIBar1002",
+		StartDate: "2018-01-07",
+		Effort:    3,
+	}
+
+	barsResponse, err := client.Bars.CreateBar(4946, barAttributes)
+	if err != nil {
+		t.Fatalf("Bars.CreateBar() returned error: %v", err)
+	}
+
+	if barsResponse.StatusCode() != 201 {
+		t.Errorf("Bars.CreateBar() status GOT: %v", barsResponse.StatusCode())
+	}
+	if barsResponse.ID != 205500 || barsResponse.Href != "/api/bars/205500" {
+		t.Errorf("Bars.CreateBar() returned GOT: %+v", barsResponse.Bar)
+	}
+	if got, want := barsResponse.ChildBars["href"], "/api/bars/205500/child_bars"; got != want {
+		t.Errorf("Bars.CreateBar() child bars link GOT: %v, WANT %v", got, want)
+	}
+}
+
+func TestBarsService_DeleteBar(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/bars/205414", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/bars/delete_bar_success.http")
+
+		testMethod(t, r, "DELETE")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	barsResponse, err := client.Bars.DeleteBar(205414)
+	if err != nil {
+		t.Fatalf("Bars.DeleteBar() returned error: %v", err)
+	}
+
+	if barsResponse.StatusCode() != 204 {
+		t.Errorf("Bars.DeleteBar() status GOT: %v", barsResponse.StatusCode())
+	}
+}
+
+// setupUpdateBar serves the update of bar 205400, and returns the attributes of the last update.
+func setupUpdateBar(t *testing.T) func() UpdateBar {
+	var got UpdateBar
+	mux.HandleFunc("/api/bars/205400", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/bars/bar_update.http")
+
+		testMethod(t, r, "PATCH")
+		testHeaders(t, r)
+		got = UpdateBar{}
+		json.NewDecoder(r.Body).Decode(&got)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+	return func() UpdateBar { return got }
+}
+
+// testBarOnRoadmap is bar 205400 of roadmap 7302, in Lane 1 and Goal 1.
+func testBarOnRoadmap() Bar {
+	return Bar{
+		ID:       205400,
+		Fields:   map[string]string{"pp_lanes": "Lane 1", "pp_legend": "Goal 1", "team": "Payments"},
+		BarLinks: BarLinks{Roadmap: map[string]string{"href": "/api/roadmaps/7302"}},
+	}
+}
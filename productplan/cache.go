@@ -0,0 +1,347 @@
+package productplan
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CachedResponse is a GET response stored in a Cache, along with its ETag.
+type CachedResponse struct {
+	ETag       string      `json:"etag"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// Cache stores GET responses, to send conditional requests with If-None-Match
+// and serve the cached response when the API answers 304 Not Modified.
+//
+// Keys combine the request URL with a hash of the credentials, see Client.Cache.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the response stored for key, if any.
+	Get(key string) (*CachedResponse, bool)
+
+	// Set stores the response for key.
+	Set(key string, response *CachedResponse)
+
+	// DeleteFunc removes every entry whose key matches.
+	DeleteFunc(match func(key string) bool)
+}
+
+// cacheKey returns the cache key of a request: a hash of its credentials, and its URL.
+func cacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Header.Get(httpHeaderAuthorization)))
+	return hex.EncodeToString(sum[:8]) + " " + req.URL.String()
+}
+
+// cacheKeyPath returns the URL path of a cache key.
+func cacheKeyPath(key string) string {
+	i := strings.IndexByte(key, ' ')
+	if i < 0 {
+		return ""
+	}
+
+	u, err := url.Parse(key[i+1:])
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
+
+// invalidatedResources returns the resources affected by a mutation, eg. bars for PATCH /api/bars/1,
+// or roadmaps and bars for POST /api/roadmaps/1/bars.
+func invalidatedResources(path string) []string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 {
+		return nil
+	}
+
+	var resources []string
+	for _, segment := range segments[1:] {
+		if _, err := strconv.Atoi(segment); err != nil && segment != "" {
+			resources = append(resources, segment)
+		}
+	}
+	return resources
+}
+
+// hasSegment reports whether a URL path contains the given segment.
+func hasSegment(path, segment string) bool {
+	for _, s := range strings.Split(path, "/") {
+		if s == segment {
+			return true
+		}
+	}
+	return false
+}
+
+// cache is the middleware sending conditional GET requests, and serving the cached
+// response on 304 Not Modified. Successful mutations invalidate the cached entries of the
+// affected resource, eg. updating a bar drops every cached response listing or showing bars.
+func (c *Client) cache(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		if c.Cache == nil {
+			return next(req)
+		}
+
+		if req.Method != "GET" {
+			resp, err := next(req)
+			if err == nil && resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+				if resources := invalidatedResources(req.URL.Path); len(resources) > 0 {
+					c.Cache.DeleteFunc(func(key string) bool {
+						path := cacheKeyPath(key)
+						for _, resource := range resources {
+							if hasSegment(path, resource) {
+								return true
+							}
+						}
+						return false
+					})
+				}
+			}
+			return resp, err
+		}
+
+		key := cacheKey(req)
+		cached, ok := c.Cache.Get(key)
+		if ok && cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+
+		resp, err := next(req)
+		if err != nil {
+			return resp, err
+		}
+
+		if resp.StatusCode == http.StatusNotModified && ok {
+			discardBody(resp)
+			updated := cached.updated(resp.Header)
+			c.Cache.Set(key, updated)
+			return updated.response(req), nil
+		}
+
+		etag := resp.Header.Get("ETag")
+		if resp.StatusCode == http.StatusOK && etag != "" {
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+
+			c.Cache.Set(key, &CachedResponse{ETag: etag, StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: body})
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		return resp, nil
+	}
+}
+
+// notModifiedSkippedHeaders describe the empty body of a 304, not the cached body.
+var notModifiedSkippedHeaders = map[string]bool{
+	"Content-Length":    true,
+	"Content-Type":      true,
+	"Content-Encoding":  true,
+	"Transfer-Encoding": true,
+}
+
+// updated returns a copy of the cached response with the headers of a 304 Not Modified
+// merged over the stored ones, so that eg. the request ID and the rate limit are the current ones.
+func (r *CachedResponse) updated(header http.Header) *CachedResponse {
+	merged := r.Header.Clone()
+	if merged == nil {
+		merged = http.Header{}
+	}
+	for name, values := range header {
+		if notModifiedSkippedHeaders[name] {
+			continue
+		}
+		merged[name] = append([]string(nil), values...)
+	}
+
+	etag := r.ETag
+	if value := header.Get("ETag"); value != "" {
+		etag = value
+	}
+	return &CachedResponse{ETag: etag, StatusCode: r.StatusCode, Header: merged, Body: r.Body}
+}
+
+// response rebuilds the cached response for req.
+func (r *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(r.StatusCode),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// MemoryCache is an in-memory Cache evicting the least recently used entries.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+type memoryCacheEntry struct {
+	key      string
+	response *CachedResponse
+}
+
+// NewMemoryCache returns a MemoryCache holding up to capacity responses.
+func NewMemoryCache(capacity int) *MemoryCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &MemoryCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get implements the Cache interface.
+func (m *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	m.order.MoveToFront(element)
+	return element.Value.(*memoryCacheEntry).response, true
+}
+
+// Set implements the Cache interface.
+func (m *MemoryCache) Set(key string, response *CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		element.Value.(*memoryCacheEntry).response = response
+		m.order.MoveToFront(element)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryCacheEntry{key: key, response: response})
+	for m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// DeleteFunc implements the Cache interface.
+func (m *MemoryCache) DeleteFunc(match func(key string) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, element := range m.entries {
+		if match(key) {
+			m.order.Remove(element)
+			delete(m.entries, key)
+		}
+	}
+}
+
+// DiskCache is a Cache storing each response as a JSON file in a directory.
+// Write errors are ignored, a response that cannot be stored is simply fetched again.
+type DiskCache struct {
+	mu  sync.Mutex
+	dir string
+}
+
+type diskCacheEntry struct {
+	Key      string          `json:"key"`
+	Response *CachedResponse `json:"response"`
+}
+
+// NewDiskCache returns a DiskCache storing the responses in dir, created if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+func (d *DiskCache) filename(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (d *DiskCache) read(filename string) (*diskCacheEntry, bool) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, false
+	}
+
+	entry := &diskCacheEntry{}
+	if err := json.Unmarshal(data, entry); err != nil || entry.Response == nil {
+		return nil, false
+	}
+	return entry, true
+}
+
+// Get implements the Cache interface.
+func (d *DiskCache) Get(key string) (*CachedResponse, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry, ok := d.read(d.filename(key))
+	if !ok || entry.Key != key {
+		return nil, false
+	}
+	return entry.Response, true
+}
+
+// Set implements the Cache interface.
+func (d *DiskCache) Set(key string, response *CachedResponse) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := json.Marshal(&diskCacheEntry{Key: key, Response: response})
+	if err != nil {
+		return
+	}
+
+	// write then rename, so that a crash never leaves a partial entry
+	filename := d.filename(key)
+	if err := ioutil.WriteFile(filename+".tmp", data, 0600); err != nil {
+		return
+	}
+	os.Rename(filename+".tmp", filename)
+}
+
+// DeleteFunc implements the Cache interface.
+func (d *DiskCache) DeleteFunc(match func(key string) bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	filenames, _ := filepath.Glob(filepath.Join(d.dir, "*.json"))
+	for _, filename := range filenames {
+		entry, ok := d.read(filename)
+		if !ok || match(entry.Key) {
+			os.Remove(filename)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package productplan
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_Cache_ConditionalGet(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	client.Cache = NewMemoryCache(10)
+
+	etag := `W/"a04b859be2282c6c5e59e5246a60e90f"`
+	var conditional []string
+	mux.HandleFunc("/api/roadmaps/7302/bars", func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		httpResponse := httpResponseFixture(t, "/roadmaps/get_bars_success.http")
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+	mux.HandleFunc("/api/bars/110240", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/bars/bar_update.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	roadmap := Roadmap{ID: 7302}
+
+	first, err := client.Roadmaps.GetBars(roadmap)
+	if err != nil {
+		t.Fatalf("Roadmaps.GetBars() returned error: %v", err)
+	}
+	second, err := client.Roadmaps.GetBars(roadmap)
+	if err != nil {
+		t.Fatalf("Roadmaps.GetBars() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(first.Items, second.Items) {
+		t.Errorf("Roadmaps.GetBars() from cache returned %+v, want %+v", second.Items, first.Items)
+	}
+
+	// updating a bar invalidates the cached bars
+	if _, err := client.Bars.UpdateBar(110240, UpdateBar{Name: "Renamed"}); err != nil {
+		t.Fatalf("Bars.UpdateBar() returned error: %v", err)
+	}
+	if _, err := client.Roadmaps.GetBars(roadmap); err != nil {
+		t.Fatalf("Roadmaps.GetBars() returned error: %v", err)
+	}
+
+	want := []string{"", etag, ""}
+	if !reflect.DeepEqual(conditional, want) {
+		t.Errorf("If-None-Match headers sent %q, want %q", conditional, want)
+	}
+}
+
+func TestClient_Cache_NotModifiedHeaders(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	client.Cache = NewMemoryCache(10)
+
+	etag := `W/"a04b859be2282c6c5e59e5246a60e90f"`
+	requests := 0
+	mux.HandleFunc("/api/roadmaps/7302/bars", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-Request-Id", fmt.Sprintf("request-%v", requests))
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(100-requests))
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		httpResponse := httpResponseFixture(t, "/roadmaps/get_bars_success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	roadmap := Roadmap{ID: 7302}
+	for i := 1; i <= 3; i++ {
+		barsResponse, err := client.Roadmaps.GetBars(roadmap)
+		if err != nil {
+			t.Fatalf("Roadmaps.GetBars() returned error: %v", err)
+		}
+
+		if got, want := barsResponse.RequestID(), fmt.Sprintf("request-%v", i); got != want {
+			t.Errorf("RequestID() of request %v = %q, want %q", i, got, want)
+		}
+		if got, want := barsResponse.RateLimit().Remaining, 100-i; got != want {
+			t.Errorf("RateLimit().Remaining of request %v = %v, want %v", i, got, want)
+		}
+		if len(barsResponse.Items) == 0 {
+			t.Errorf("Roadmaps.GetBars() of request %v returned no bars", i)
+		}
+	}
+
+	for key, element := range client.Cache.(*MemoryCache).entries {
+		cached := element.Value.(*memoryCacheEntry).response
+		if got := cached.Header.Get("X-Request-Id"); got != "request-3" {
+			t.Errorf("cached %v X-Request-Id = %q, want the one of the last 304", key, got)
+		}
+	}
+}
+
+func TestCacheKey_Credentials(t *testing.T) {
+	c1 := NewClient("https://go.example.com", NewOauthTokenCredentials("token-1"))
+	c2 := NewClient("https://go.example.com", NewOauthTokenCredentials("token-2"))
+
+	req1, _ := c1.NewRequest("GET", "/api/bars", nil)
+	req2, _ := c2.NewRequest("GET", "/api/bars", nil)
+
+	if cacheKey(req1) == cacheKey(req2) {
+		t.Errorf("cacheKey() returned the same key for different credentials")
+	}
+	if got := cacheKeyPath(cacheKey(req1)); got != "/api/bars" {
+		t.Errorf("cacheKeyPath() returned %v, want %v", got, "/api/bars")
+	}
+}
+
+func testCache(t *testing.T, cache Cache) {
+	response := &CachedResponse{ETag: `"1"`, StatusCode: 200, Header: http.Header{"Etag": {`"1"`}}, Body: []byte(`[]`)}
+
+	cache.Set("k /api/bars/1", response)
+	cache.Set("k /api/roadmaps/1/bars", response)
+	cache.Set("k /api/ideas/1", response)
+
+	got, ok := cache.Get("k /api/bars/1")
+	if !ok || !reflect.DeepEqual(got, response) {
+		t.Errorf("Cache.Get() returned %+v, %v, want %+v", got, ok, response)
+	}
+
+	cache.DeleteFunc(func(key string) bool { return hasSegment(cacheKeyPath(key), "bars") })
+
+	for key, want := range map[string]bool{"k /api/bars/1": false, "k /api/roadmaps/1/bars": false, "k /api/ideas/1": true} {
+		if _, ok := cache.Get(key); ok != want {
+			t.Errorf("Cache.Get(%q) found %v, want %v", key, ok, want)
+		}
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	testCache(t, NewMemoryCache(10))
+}
+
+func TestMemoryCache_Eviction(t *testing.T) {
+	cache := NewMemoryCache(2)
+	response := &CachedResponse{ETag: `"1"`}
+
+	cache.Set("a", response)
+	cache.Set("b", response)
+	cache.Get("a")
+	cache.Set("c", response)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("MemoryCache kept the least recently used entry")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Errorf("MemoryCache evicted a recently used entry")
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache() returned error: %v", err)
+	}
+	testCache(t, cache)
+}
+
+func TestInvalidatedResources(t *testing.T) {
+	tests := map[string][]string{
+		"/api/bars/205414":        {"bars"},
+		"/api/roadmaps/4946/bars": {"roadmaps", "bars"},
+		"/api":                    nil,
+	}
+
+	for path, want := range tests {
+		if got := invalidatedResources(path); !reflect.DeepEqual(got, want) {
+			t.Errorf("invalidatedResources(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package productplan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Environment variables read by the default credentials chain.
+const (
+	envToken            = "PRODUCTPLAN_TOKEN"
+	envBaseURL          = "PRODUCTPLAN_BASE_URL"
+	envProfile          = "PRODUCTPLAN_PROFILE"
+	envConfigFile       = "PRODUCTPLAN_CONFIG_FILE"
+	envCredentialHelper = "PRODUCTPLAN_CREDENTIAL_HELPER"
+
+	defaultProfileName = "default"
+)
+
+// ErrNoCredentials is returned by a CredentialsProvider that has no credentials to offer,
+// so that the chain moves on to the next provider.
+var ErrNoCredentials = errors.New("productplan: no credentials found")
+
+// Profile is the outcome of a CredentialsProvider: an access token,
+// and optionally the base URL of the API to use it with.
+type Profile struct {
+	// Name of the profile, for the providers supporting several of them.
+	Name string
+
+	Token   string
+	BaseURL string
+
+	// Source describes where the credentials were found, eg. "env" or the config file path.
+	Source string
+}
+
+// CredentialsProvider finds the credentials of a Profile.
+// It returns ErrNoCredentials when it has none, and any other error when its source is unusable.
+type CredentialsProvider interface {
+	Resolve(ctx context.Context) (*Profile, error)
+}
+
+// StaticProvider provides an explicit token.
+type StaticProvider struct {
+	Token   string
+	BaseURL string
+}
+
+// Resolve implements the CredentialsProvider interface.
+func (p *StaticProvider) Resolve(ctx context.Context) (*Profile, error) {
+	if p.Token == "" {
+		return nil, ErrNoCredentials
+	}
+	return &Profile{Token: p.Token, BaseURL: p.BaseURL, Source: "static"}, nil
+}
+
+// EnvProvider reads the token and the base URL from environment variables,
+// PRODUCTPLAN_TOKEN and PRODUCTPLAN_BASE_URL by default.
+type EnvProvider struct {
+	TokenVar   string
+	BaseURLVar string
+}
+
+// Resolve implements the CredentialsProvider interface.
+func (p *EnvProvider) Resolve(ctx context.Context) (*Profile, error) {
+	tokenVar, baseURLVar := p.TokenVar, p.BaseURLVar
+	if tokenVar == "" {
+		tokenVar = envToken
+	}
+	if baseURLVar == "" {
+		baseURLVar = envBaseURL
+	}
+
+	token := os.Getenv(tokenVar)
+	if token == "" {
+		return nil, ErrNoCredentials
+	}
+	return &Profile{Token: token, BaseURL: os.Getenv(baseURLVar), Source: "env"}, nil
+}
+
+// FileProvider reads a named profile from a config file with one section per profile:
+//
+//	[default]
+//	token = ...
+//	base_url = https://app.productplan.com
+//
+//	[staging]
+//	token = ...
+//
+// Path defaults to PRODUCTPLAN_CONFIG_FILE, or ~/.productplan/credentials.
+// Profile defaults to PRODUCTPLAN_PROFILE, or "default".
+type FileProvider struct {
+	Path    string
+	Profile string
+}
+
+// Resolve implements the CredentialsProvider interface.
+// A missing file, or a missing profile, is not an error.
+func (p *FileProvider) Resolve(ctx context.Context) (*Profile, error) {
+	path := p.Path
+	if path == "" {
+		path = os.Getenv(envConfigFile)
+	}
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, ErrNoCredentials
+		}
+		path = filepath.Join(home, ".productplan", "credentials")
+	}
+
+	name := p.Profile
+	if name == "" {
+		name = os.Getenv(envProfile)
+	}
+	if name == "" {
+		name = defaultProfileName
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	profiles, err := parseProfiles(file)
+	if err != nil {
+		return nil, fmt.Errorf("productplan: reading %v: %w", path, err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok || profile.Token == "" {
+		return nil, ErrNoCredentials
+	}
+	profile.Source = path
+	return profile, nil
+}
+
+// parseProfiles parses the profile sections of a config file.
+// Blank lines, and lines starting with # or ;, are ignored.
+func parseProfiles(r io.Reader) (map[string]*Profile, error) {
+	profiles := make(map[string]*Profile)
+	var profile *Profile
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			name := strings.TrimSpace(text[1 : len(text)-1])
+			profile = &Profile{Name: name}
+			profiles[name] = profile
+			continue
+		}
+
+		kv := strings.SplitN(text, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("line %v: expected key = value", line)
+		}
+		if profile == nil {
+			return nil, fmt.Errorf("line %v: key outside of a [profile] section", line)
+		}
+
+		switch key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]); key {
+		case "token":
+			profile.Token = value
+		case "base_url":
+			profile.BaseURL = value
+		default:
+			return nil, fmt.Errorf("line %v: unknown key %q", line, key)
+		}
+	}
+
+	return profiles, scanner.Err()
+}
+
+// CommandProvider runs an external credential helper, in the manner of git credential helpers.
+// The command is run with the additional "get" argument, and prints key=value lines on its
+// standard output: token, and optionally base_url. An empty output means no credentials.
+//
+// Command defaults to PRODUCTPLAN_CREDENTIAL_HELPER, split on spaces.
+type CommandProvider struct {
+	Command string
+	Args    []string
+}
+
+// Resolve implements the CredentialsProvider interface.
+func (p *CommandProvider) Resolve(ctx context.Context) (*Profile, error) {
+	command, args := p.Command, p.Args
+	if command == "" {
+		fields := strings.Fields(os.Getenv(envCredentialHelper))
+		if len(fields) == 0 {
+			return nil, ErrNoCredentials
+		}
+		command, args = fields[0], fields[1:]
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, append(args, "get")...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("productplan: credential helper %v: %w: %v", command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	profile := &Profile{Source: command}
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		kv := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "token":
+			profile.Token = kv[1]
+		case "base_url":
+			profile.BaseURL = kv[1]
+		}
+	}
+
+	if profile.Token == "" {
+		return nil, ErrNoCredentials
+	}
+	return profile, nil
+}
+
+// CredentialsChain is Credentials trying each provider in order, and using the first Profile found.
+// The profile is resolved once, on first use, and then reused.
+type CredentialsChain struct {
+	Providers []CredentialsProvider
+
+	mu      sync.Mutex
+	profile *Profile
+}
+
+// NewCredentialsChain construct Credentials trying the given providers in order.
+func NewCredentialsChain(providers ...CredentialsProvider) *CredentialsChain {
+	return &CredentialsChain{Providers: providers}
+}
+
+// DefaultCredentialsChain construct Credentials trying, in order: the explicit token if not empty,
+// the environment variables, the config file profile and the credential helper command.
+func DefaultCredentialsChain(token string) *CredentialsChain {
+	return NewCredentialsChain(
+		&StaticProvider{Token: token},
+		&EnvProvider{},
+		&FileProvider{},
+		&CommandProvider{},
+	)
+}
+
+// Resolve returns the Profile of the first provider having credentials.
+// It stops at the first provider failing with an error other than ErrNoCredentials.
+func (c *CredentialsChain) Resolve(ctx context.Context) (*Profile, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.profile != nil {
+		return c.profile, nil
+	}
+
+	for _, provider := range c.Providers {
+		profile, err := provider.Resolve(ctx)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		c.profile = profile
+		return profile, nil
+	}
+
+	return nil, ErrNoCredentials
+}
+
+// Headers returns the authentication headers, without any header when no credentials are found.
+// The client uses HeadersWithContext, which reports the errors.
+func (c *CredentialsChain) Headers() map[string]string {
+	headers, err := c.HeadersWithContext(context.Background())
+	if err != nil {
+		return map[string]string{}
+	}
+	return headers
+}
+
+// HeadersWithContext returns the authentication headers of the resolved profile.
+func (c *CredentialsChain) HeadersWithContext(ctx context.Context) (map[string]string, error) {
+	profile, err := c.Resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewOauthTokenCredentials(profile.Token).Headers(), nil
+}
@@ -0,0 +1,179 @@
+package productplan
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// clearCredentialsEnv unsets the environment variables read by the default chain.
+func clearCredentialsEnv(t *testing.T) {
+	for _, name := range []string{envToken, envBaseURL, envProfile, envConfigFile, envCredentialHelper} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("HOME", t.TempDir())
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const testConfigFile = `
+# ProductPlan credentials
+[default]
+token = default-token
+
+[staging]
+token = staging-token
+base_url = https://staging.example.com
+`
+
+func TestCredentialsChain_Order(t *testing.T) {
+	clearCredentialsEnv(t)
+	t.Setenv(envConfigFile, writeConfigFile(t, testConfigFile))
+
+	profile, err := DefaultCredentialsChain("").Resolve(context.Background())
+	if err != nil {
+		t.Fatalf("Resolve() returned error: %v", err)
+	}
+	if profile.Token != "default-token" {
+		t.Errorf("Resolve() token %v, want the config file token", profile.Token)
+	}
+
+	t.Setenv(envToken, "env-token")
+	t.Setenv(envBaseURL, "https://env.example.com")
+	profile, _ = DefaultCredentialsChain("").Resolve(context.Background())
+	if want := (&Profile{Token: "env-token", BaseURL: "https://env.example.com", Source: "env"}); !reflect.DeepEqual(profile, want) {
+		t.Errorf("Resolve() returned %+v, want %+v", profile, want)
+	}
+
+	profile, _ = DefaultCredentialsChain("explicit-token").Resolve(context.Background())
+	if profile.Token != "explicit-token" {
+		t.Errorf("Resolve() token %v, want the explicit token", profile.Token)
+	}
+}
+
+func TestCredentialsChain_NoCredentials(t *testing.T) {
+	clearCredentialsEnv(t)
+
+	chain := DefaultCredentialsChain("")
+	if _, err := chain.Resolve(context.Background()); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("Resolve() error = %v, want %v", err, ErrNoCredentials)
+	}
+	if headers := chain.Headers(); len(headers) != 0 {
+		t.Errorf("Headers() returned %v, want none", headers)
+	}
+}
+
+func TestFileProvider_Profile(t *testing.T) {
+	clearCredentialsEnv(t)
+	path := writeConfigFile(t, testConfigFile)
+	t.Setenv(envProfile, "staging")
+
+	profile, err := (&FileProvider{Path: path}).Resolve(context.Background())
+	if err != nil {
+		t.Fatalf("Resolve() returned error: %v", err)
+	}
+
+	want := &Profile{Name: "staging", Token: "staging-token", BaseURL: "https://staging.example.com", Source: path}
+	if !reflect.DeepEqual(profile, want) {
+		t.Errorf("Resolve() returned %+v, want %+v", profile, want)
+	}
+
+	_, err = (&FileProvider{Path: path, Profile: "production"}).Resolve(context.Background())
+	if !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("Resolve() error = %v, want %v", err, ErrNoCredentials)
+	}
+}
+
+func TestFileProvider_Malformed(t *testing.T) {
+	path := writeConfigFile(t, "[default]\ntoken = a\npasword = b\n")
+
+	_, err := NewCredentialsChain(&FileProvider{Path: path}, &StaticProvider{Token: "fallback"}).Resolve(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Resolve() error = %v, want the malformed line", err)
+	}
+}
+
+// flakyProvider fails the first time, then provides its profile.
+type flakyProvider struct {
+	profile *Profile
+	calls   int
+}
+
+func (p *flakyProvider) Resolve(ctx context.Context) (*Profile, error) {
+	p.calls++
+	if p.calls == 1 {
+		return nil, errors.New("credential helper unavailable")
+	}
+	return p.profile, nil
+}
+
+func TestWithCredentialsChain(t *testing.T) {
+	provider := &flakyProvider{profile: &Profile{Token: "staging-token", BaseURL: "https://staging.example.com"}}
+	client := NewClient("https://app.productplan.com", nil, WithCredentialsChain(NewCredentialsChain(provider)), WithProfile(nil))
+	if provider.calls != 0 {
+		t.Errorf("NewClient() resolved the chain %v times, want it resolved by the first request", provider.calls)
+	}
+
+	if _, err := client.NewRequest("GET", "/api/status", nil); err == nil {
+		t.Fatalf("NewRequest() expected the error of the chain")
+	}
+
+	req, err := client.NewRequest("GET", "/api/status", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() returned error: %v", err)
+	}
+	if got, want := req.URL.String(), "https://staging.example.com/api/status"; got != want {
+		t.Errorf("NewRequest() URL %v, want %v", got, want)
+	}
+	if got, want := req.Header.Get(httpHeaderAuthorization), "Bearer staging-token"; got != want {
+		t.Errorf("NewRequest() Authorization %v, want %v", got, want)
+	}
+}
+
+func TestCommandProvider(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+	clearCredentialsEnv(t)
+
+	helper := filepath.Join(t.TempDir(), "helper")
+	script := "#!/bin/sh\n[ \"$1\" = get ] || exit 1\necho token=helper-token\necho base_url=https://helper.example.com\n"
+	if err := os.WriteFile(helper, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(envCredentialHelper, helper)
+
+	chain := DefaultCredentialsChain("")
+	testCredentials(t, chain, map[string]string{httpHeaderAuthorization: "Bearer helper-token"})
+
+	profile, _ := chain.Resolve(context.Background())
+	client := NewClient("https://app.productplan.com", nil, WithProfile(profile))
+	if client.BaseURL != "https://helper.example.com" {
+		t.Errorf("WithProfile() BaseURL %v, want the profile base URL", client.BaseURL)
+	}
+
+	client = NewClient("https://app.productplan.com", nil, WithCredentialsChain(DefaultCredentialsChain("")))
+	req, err := client.NewRequest("GET", "/api/status", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() returned error: %v", err)
+	}
+	if got, want := req.URL.String(), "https://helper.example.com/api/status"; got != want {
+		t.Errorf("WithCredentialsChain() request URL %v, want %v", got, want)
+	}
+
+	_, err = (&CommandProvider{Command: "false"}).Resolve(context.Background())
+	if err == nil || errors.Is(err, ErrNoCredentials) {
+		t.Errorf("Resolve() error = %v, want the helper failure", err)
+	}
+}
@@ -0,0 +1,158 @@
+package productplan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CustomFieldsService handles communication with the custom field
+// methods of the Productplan API.
+type CustomFieldsService struct {
+	client *Client
+}
+
+// CustomFieldType is the type of the values of a custom field.
+type CustomFieldType string
+
+// Types of custom fields.
+const (
+	CustomFieldText     CustomFieldType = "text"
+	CustomFieldNumber   CustomFieldType = "number"
+	CustomFieldDate     CustomFieldType = "date"
+	CustomFieldDropdown CustomFieldType = "dropdown"
+)
+
+// CustomField represents a custom field defined on a roadmap.
+// Key is the key of the field in Bar.Fields and Ideas.Fields, eg. pp_lanes.
+type CustomField struct {
+	Href string          `json:"href,omitempty"`
+	ID   int             `json:"id"`
+	Key  string          `json:"key"`
+	Name string          `json:"name"`
+	Type CustomFieldType `json:"type"`
+
+	// Values allowed for a dropdown field.
+	Values []string `json:"values,omitempty"`
+}
+
+// ListCustomFields get the custom fields defined on a roadmap
+func (s *CustomFieldsService) ListCustomFields(roadmap Roadmap) (*ListResponse[CustomField], error) {
+	return s.ListCustomFieldsWithContext(context.Background(), roadmap, nil)
+}
+
+// ListCustomFieldsWithContext get a page of the custom fields defined on a roadmap using the given context,
+// following the custom fields link of the roadmap when available.
+// Use options.Cursor to select the page to return.
+func (s *CustomFieldsService) ListCustomFieldsWithContext(ctx context.Context, roadmap Roadmap, options *ListOptions) (*ListResponse[CustomField], error) {
+	path := roadmap.RoadmapLinks.CustomFields["href"]
+	if path == "" {
+		path = fmt.Sprintf("/api/roadmaps/%v/custom_fields", roadmap.ID)
+	}
+	return getList[CustomField](ctx, s.client, path, options)
+}
+
+// IterCustomFields iterates over all the custom fields defined on a roadmap, fetching the pages as needed.
+func (s *CustomFieldsService) IterCustomFields(ctx context.Context, roadmap Roadmap, options *ListOptions) *Iterator[CustomField] {
+	return newIterator(ctx, func(ctx context.Context, cursor *Cursor) (*ListResponse[CustomField], error) {
+		return s.ListCustomFieldsWithContext(ctx, roadmap, withCursor(options, cursor))
+	})
+}
+
+// ValidateFields fetches the custom fields defined on a roadmap, and validates fields against them
+// with the package-level ValidateFields function.
+func (s *CustomFieldsService) ValidateFields(roadmap Roadmap, fields map[string]string) error {
+	return s.ValidateFieldsWithContext(context.Background(), roadmap, fields)
+}
+
+// ValidateFieldsWithContext validates fields against the custom fields defined on a roadmap using the given context,
+// see the package-level ValidateFields function.
+func (s *CustomFieldsService) ValidateFieldsWithContext(ctx context.Context, roadmap Roadmap, fields map[string]string) error {
+	definitions, err := ListAll(s.IterCustomFields(ctx, roadmap, nil), 0)
+	if err != nil {
+		return err
+	}
+	return ValidateFields(definitions, fields)
+}
+
+// FieldsError lists the invalid entries of a Fields map by key. It matches ErrValidation.
+type FieldsError struct {
+	Errors map[string][]string
+}
+
+// Error implements the error interface.
+func (e *FieldsError) Error() string {
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	details := make([]string, 0, len(keys))
+	for _, key := range keys {
+		details = append(details, fmt.Sprintf("%v %v", key, strings.Join(e.Errors[key], ", ")))
+	}
+	return fmt.Sprintf("productplan: invalid fields (%v)", strings.Join(details, "; "))
+}
+
+// Is matches ErrValidation, like the validation errors returned by the API.
+func (e *FieldsError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// ValidateFields validates the Fields of a bar or an idea against the custom fields of its roadmap,
+// eg. before UpdateBar or Import. It returns a *FieldsError listing the unknown keys,
+// the values not allowed by dropdown fields, and the malformed numbers and dates.
+func ValidateFields(definitions []CustomField, fields map[string]string) error {
+	byKey := make(map[string]CustomField, len(definitions))
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+
+	fieldsError := &FieldsError{Errors: make(map[string][]string)}
+	for key, value := range fields {
+		definition, ok := byKey[key]
+		if !ok {
+			fieldsError.Errors[key] = append(fieldsError.Errors[key], "is not a custom field of the roadmap")
+			continue
+		}
+		if message := definition.validate(value); message != "" {
+			fieldsError.Errors[key] = append(fieldsError.Errors[key], message)
+		}
+	}
+
+	if len(fieldsError.Errors) > 0 {
+		return fieldsError
+	}
+	return nil
+}
+
+// validate returns why value is not valid for the field, or an empty string.
+// An empty value clears the field, and is always valid.
+func (f CustomField) validate(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	switch f.Type {
+	case CustomFieldNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Sprintf("%q is not a number", value)
+		}
+	case CustomFieldDate:
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return fmt.Sprintf("%q is not a YYYY-MM-DD date", value)
+		}
+	case CustomFieldDropdown:
+		for _, allowed := range f.Values {
+			if value == allowed {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%q is not one of %v", value, strings.Join(f.Values, ", "))
+	}
+	return ""
+}
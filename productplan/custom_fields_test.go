@@ -0,0 +1,84 @@
+package productplan
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func setupCustomFields(t *testing.T) {
+	mux.HandleFunc("/api/roadmaps/7302/custom_fields", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/roadmaps/get_custom_fields_success.http")
+
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+}
+
+func TestCustomFieldsService_ListCustomFields(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	setupCustomFields(t)
+
+	roadmap := Roadmap{ID: 7302, RoadmapLinks: RoadmapLinks{CustomFields: map[string]string{"href": "/api/roadmaps/7302/custom_fields"}}}
+
+	customFieldsResponse, err := client.CustomFields.ListCustomFields(roadmap)
+	if err != nil {
+		t.Fatalf("CustomFields.ListCustomFields() returned error: %v", err)
+	}
+
+	want := CustomField{
+		Href:   "/api/roadmaps/7302/custom_fields/901",
+		ID:     901,
+		Key:    "pp_lanes",
+		Name:   "Lane",
+		Type:   CustomFieldDropdown,
+		Values: []string{"Lane 1", "Lane 2", "Lane 3"},
+	}
+
+	if len(customFieldsResponse.Items) != 5 {
+		t.Fatalf("CustomFields.ListCustomFields() returned %v fields, want %v", len(customFieldsResponse.Items), 5)
+	}
+	if got := customFieldsResponse.Items[0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("CustomFields.ListCustomFields() returned GOT: %+v, WANT %+v", got, want)
+	}
+}
+
+func TestCustomFieldsService_ValidateFields(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	setupCustomFields(t)
+
+	roadmap := Roadmap{ID: 7302}
+
+	valid := map[string]string{"pp_lanes": "Lane 2", "pp_legend": "", "story_points": "3.5", "launch_date": "2018-10-01", "team": "Payments"}
+	if err := client.CustomFields.ValidateFields(roadmap, valid); err != nil {
+		t.Errorf("CustomFields.ValidateFields() returned error: %v", err)
+	}
+
+	invalid := map[string]string{"pp_lanes": "Lane 9", "story_points": "three", "launch_date": "10/01/2018", "owner": "ana"}
+	err := client.CustomFields.ValidateFieldsWithContext(context.Background(), roadmap, invalid)
+
+	var fieldsError *FieldsError
+	if !errors.As(err, &fieldsError) {
+		t.Fatalf("CustomFields.ValidateFields() error = %v, want a *FieldsError", err)
+	}
+	want := map[string][]string{
+		"pp_lanes":     {`"Lane 9" is not one of Lane 1, Lane 2, Lane 3`},
+		"story_points": {`"three" is not a number`},
+		"launch_date":  {`"10/01/2018" is not a YYYY-MM-DD date`},
+		"owner":        {"is not a custom field of the roadmap"},
+	}
+	if !reflect.DeepEqual(fieldsError.Errors, want) {
+		t.Errorf("FieldsError.Errors GOT: %v, WANT %v", fieldsError.Errors, want)
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("CustomFields.ValidateFields() error %v should match %v", err, ErrValidation)
+	}
+}
@@ -0,0 +1,151 @@
+package productplan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// maxErrorReadSize is the maximum size of an error body read from the API.
+	maxErrorReadSize = 64 * 1024
+
+	// maxErrorBodySize is the maximum size of the raw body kept in an ErrorResponse.
+	maxErrorBodySize = 1024
+)
+
+// Sentinel errors matching the API errors by status code, to be used with errors.Is.
+var (
+	ErrUnauthorized = errors.New("productplan: unauthorized")
+	ErrForbidden    = errors.New("productplan: forbidden")
+	ErrNotFound     = errors.New("productplan: not found")
+	ErrConflict     = errors.New("productplan: conflict")
+	ErrValidation   = errors.New("productplan: validation failed")
+	ErrRateLimited  = errors.New("productplan: rate limited")
+)
+
+var statusErrors = map[int]error{
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusConflict:            ErrConflict,
+	http.StatusUnprocessableEntity: ErrValidation,
+	http.StatusTooManyRequests:     ErrRateLimited,
+}
+
+// errorBody represents the JSON body of an API error.
+type errorBody struct {
+	Message string          `json:"message"`
+	Errors  json.RawMessage `json:"errors"`
+}
+
+// ValidationError represents a 422 response.
+// Errors holds the validation messages by field name.
+type ValidationError struct {
+	*ErrorResponse
+	Errors map[string][]string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	if len(e.Errors) == 0 {
+		return e.ErrorResponse.Error()
+	}
+
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var details []string
+	for _, field := range fields {
+		details = append(details, fmt.Sprintf("%v %v", field, strings.Join(e.Errors[field], ", ")))
+	}
+	return fmt.Sprintf("%v (%v)", e.ErrorResponse.Error(), strings.Join(details, "; "))
+}
+
+// Unwrap returns the underlying ErrorResponse.
+func (e *ValidationError) Unwrap() error {
+	return e.ErrorResponse
+}
+
+// RateLimitError represents a 429 response.
+type RateLimitError struct {
+	*ErrorResponse
+
+	// RetryAfter is the delay requested by the server before retrying, zero if unknown.
+	RetryAfter time.Duration
+
+	// RateLimit is the rate-limit information of the response.
+	RateLimit RateLimit
+}
+
+// Unwrap returns the underlying ErrorResponse.
+func (e *RateLimitError) Unwrap() error {
+	return e.ErrorResponse
+}
+
+// newStatusError returns the most specific error type for the status code of the response.
+func newStatusError(errorResponse *ErrorResponse, body errorBody, resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusUnprocessableEntity:
+		return &ValidationError{ErrorResponse: errorResponse, Errors: parseValidationErrors(body.Errors)}
+	case http.StatusTooManyRequests:
+		now := time.Now()
+		retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"), now)
+		return &RateLimitError{ErrorResponse: errorResponse, RetryAfter: retryAfter, RateLimit: parseRateLimit(resp.Header, now)}
+	}
+	return errorResponse
+}
+
+// parseValidationErrors decodes the per-field errors of a 422 response.
+// Both {"field": ["message"]} and [{"field": "...", "message": "..."}] forms are accepted.
+func parseValidationErrors(data json.RawMessage) map[string][]string {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var byField map[string]json.RawMessage
+	if json.Unmarshal(data, &byField) == nil {
+		errs := make(map[string][]string, len(byField))
+		for field, raw := range byField {
+			var messages []string
+			if json.Unmarshal(raw, &messages) != nil {
+				var message string
+				if json.Unmarshal(raw, &message) != nil {
+					continue
+				}
+				messages = []string{message}
+			}
+			errs[field] = messages
+		}
+		return errs
+	}
+
+	var list []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(data, &list) == nil {
+		errs := make(map[string][]string, len(list))
+		for _, e := range list {
+			errs[e.Field] = append(errs[e.Field], e.Message)
+		}
+		return errs
+	}
+
+	return nil
+}
+
+// truncate shortens s to at most max bytes.
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}
@@ -0,0 +1,131 @@
+package productplan
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckResponse_NotFound(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/ideas/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "895f62d8-df96-405c-8ab7-c982df1b0a75")
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"message":"Idea not found"}`)
+	})
+
+	_, err := client.Ideas.Show("1")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Ideas.Show() error = %v, want %v", err, ErrNotFound)
+	}
+	if errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Ideas.Show() error %v should not match %v", err, ErrUnauthorized)
+	}
+
+	var errorResponse *ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		t.Fatalf("Ideas.Show() error %T is not an *ErrorResponse", err)
+	}
+	if errorResponse.Message != "Idea not found" {
+		t.Errorf("ErrorResponse.Message = %v", errorResponse.Message)
+	}
+	if errorResponse.RequestID != "895f62d8-df96-405c-8ab7-c982df1b0a75" {
+		t.Errorf("ErrorResponse.RequestID = %v", errorResponse.RequestID)
+	}
+}
+
+func TestCheckResponse_NotJSON(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	page := "<html><body><h1>502 Bad Gateway</h1>" + strings.Repeat(" ", 2*maxErrorBodySize) + "</body></html>"
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		io.WriteString(w, page)
+	})
+
+	_, err := client.Status.GetStatus()
+
+	var errorResponse *ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		t.Fatalf("Status.GetStatus() error %v is not an *ErrorResponse", err)
+	}
+	if errorResponse.StatusCode != http.StatusBadGateway {
+		t.Errorf("ErrorResponse.StatusCode = %v, want %v", errorResponse.StatusCode, http.StatusBadGateway)
+	}
+	if !strings.HasPrefix(errorResponse.Body, "<html>") || len(errorResponse.Body) > maxErrorBodySize+3 {
+		t.Errorf("ErrorResponse.Body = %q", errorResponse.Body)
+	}
+	if !strings.HasSuffix(err.Error(), "502 Bad Gateway") {
+		t.Errorf("ErrorResponse.Error() = %v", err.Error())
+	}
+}
+
+func TestCheckResponse_Validation(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/bars/205400", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		io.WriteString(w, `{"message":"Validation failed","errors":{"end_date":["must be after start date"],"name":"can't be blank"}}`)
+	})
+
+	_, err := client.Bars.UpdateBar(205400, UpdateBar{EndDate: "2017-01-01"})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("Bars.UpdateBar() error = %v, want %v", err, ErrValidation)
+	}
+
+	var validationError *ValidationError
+	if !errors.As(err, &validationError) {
+		t.Fatalf("Bars.UpdateBar() error %T is not a *ValidationError", err)
+	}
+
+	want := map[string][]string{"end_date": {"must be after start date"}, "name": {"can't be blank"}}
+	if !reflect.DeepEqual(validationError.Errors, want) {
+		t.Errorf("ValidationError.Errors = %v, want %v", validationError.Errors, want)
+	}
+
+	var errorResponse *ErrorResponse
+	if !errors.As(err, &errorResponse) || errorResponse.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Bars.UpdateBar() error %v does not unwrap to an *ErrorResponse", err)
+	}
+}
+
+func TestCheckResponse_RateLimited(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		io.WriteString(w, `{"message":"Too many requests"}`)
+	})
+
+	_, err := client.Status.GetStatus()
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Status.GetStatus() error = %v, want %v", err, ErrRateLimited)
+	}
+
+	var rateLimitError *RateLimitError
+	if !errors.As(err, &rateLimitError) {
+		t.Fatalf("Status.GetStatus() error %T is not a *RateLimitError", err)
+	}
+	if rateLimitError.RetryAfter != 30*time.Second {
+		t.Errorf("RateLimitError.RetryAfter = %v, want %v", rateLimitError.RetryAfter, 30*time.Second)
+	}
+}
+
+func TestParseValidationErrors_List(t *testing.T) {
+	got := parseValidationErrors([]byte(`[{"field":"name","message":"can't be blank"},{"field":"name","message":"is too short"}]`))
+	want := map[string][]string{"name": {"can't be blank", "is too short"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseValidationErrors() = %v, want %v", got, want)
+	}
+}
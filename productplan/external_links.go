@@ -1,6 +1,129 @@
 package productplan
 
-// ExternalLinks associated with an idea
-type ExternalLinks struct {
-	Href []string `json:"href,omitempty"`
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// ExternalLink represents a link from a bar or an idea to an external resource, eg. a ticket or a pull request
+type ExternalLink struct {
+	Href  string `json:"href,omitempty"`
+	ID    int    `json:"id,omitempty"`
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+}
+
+// ExternalLinksResponse represents a response from an API method that returns an ExternalLink struct.
+type ExternalLinksResponse struct {
+	Response
+	ExternalLink
+}
+
+// externalLinksPath returns the path of the external links of a bar or an idea,
+// from its links when available.
+func externalLinksPath(links map[string]string, resource string, id int) string {
+	if href := links["href"]; href != "" {
+		return href
+	}
+	return fmt.Sprintf("/api/%v/%v/external_links", resource, id)
+}
+
+func (c *Client) listExternalLinks(ctx context.Context, path string, options *ListOptions) (*ListResponse[ExternalLink], error) {
+	return getList[ExternalLink](ctx, c, path, options)
+}
+
+func (c *Client) addExternalLink(ctx context.Context, path string, link ExternalLink) (*ExternalLinksResponse, error) {
+	externalLinksResponse := &ExternalLinksResponse{}
+
+	resp, err := c.post(ctx, path, link, externalLinksResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	externalLinksResponse.Response = newResponse(resp)
+	return externalLinksResponse, nil
+}
+
+func (c *Client) removeExternalLink(ctx context.Context, path string, id int) (*ExternalLinksResponse, error) {
+	externalLinksResponse := &ExternalLinksResponse{}
+
+	resp, err := c.delete(ctx, fmt.Sprintf("%v/%v", path, id), externalLinksResponse)
+
+	// delete does not return any response data, so expect the response decode to be EOF
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	externalLinksResponse.Response = newResponse(resp)
+	return externalLinksResponse, nil
+}
+
+// ListExternalLinks get the external links of a bar
+func (s *BarsService) ListExternalLinks(bar Bar) (*ListResponse[ExternalLink], error) {
+	return s.ListExternalLinksWithContext(context.Background(), bar, nil)
+}
+
+// ListExternalLinksWithContext get a page of the external links of a bar using the given context
+func (s *BarsService) ListExternalLinksWithContext(ctx context.Context, bar Bar, options *ListOptions) (*ListResponse[ExternalLink], error) {
+	return s.client.listExternalLinks(ctx, externalLinksPath(bar.ExternalLinks, "bars", bar.ID), options)
+}
+
+// AddExternalLink adds an external link to a bar, and returns the new link with its ID
+func (s *BarsService) AddExternalLink(bar Bar, link ExternalLink) (*ExternalLinksResponse, error) {
+	return s.AddExternalLinkWithContext(context.Background(), bar, link)
+}
+
+// AddExternalLinkWithContext adds an external link to a bar using the given context
+func (s *BarsService) AddExternalLinkWithContext(ctx context.Context, bar Bar, link ExternalLink) (*ExternalLinksResponse, error) {
+	return s.client.addExternalLink(ctx, externalLinksPath(bar.ExternalLinks, "bars", bar.ID), link)
+}
+
+// RemoveExternalLink removes an external link from a bar
+func (s *BarsService) RemoveExternalLink(bar Bar, linkID int) (*ExternalLinksResponse, error) {
+	return s.RemoveExternalLinkWithContext(context.Background(), bar, linkID)
+}
+
+// RemoveExternalLinkWithContext removes an external link from a bar using the given context
+func (s *BarsService) RemoveExternalLinkWithContext(ctx context.Context, bar Bar, linkID int) (*ExternalLinksResponse, error) {
+	return s.client.removeExternalLink(ctx, externalLinksPath(bar.ExternalLinks, "bars", bar.ID), linkID)
+}
+
+// ideaExternalLinksPath returns the path of the external links of an idea.
+func ideaExternalLinksPath(idea Ideas) string {
+	var links map[string]string
+	if idea.IdeaLinks != nil {
+		links = idea.IdeaLinks.ExternalLinks
+	}
+	return externalLinksPath(links, "ideas", idea.ID)
+}
+
+// ListExternalLinks get the external links of an idea
+func (s *IdeasService) ListExternalLinks(idea Ideas) (*ListResponse[ExternalLink], error) {
+	return s.ListExternalLinksWithContext(context.Background(), idea, nil)
+}
+
+// ListExternalLinksWithContext get a page of the external links of an idea using the given context
+func (s *IdeasService) ListExternalLinksWithContext(ctx context.Context, idea Ideas, options *ListOptions) (*ListResponse[ExternalLink], error) {
+	return s.client.listExternalLinks(ctx, ideaExternalLinksPath(idea), options)
+}
+
+// AddExternalLink adds an external link to an idea, and returns the new link with its ID
+func (s *IdeasService) AddExternalLink(idea Ideas, link ExternalLink) (*ExternalLinksResponse, error) {
+	return s.AddExternalLinkWithContext(context.Background(), idea, link)
+}
+
+// AddExternalLinkWithContext adds an external link to an idea using the given context
+func (s *IdeasService) AddExternalLinkWithContext(ctx context.Context, idea Ideas, link ExternalLink) (*ExternalLinksResponse, error) {
+	return s.client.addExternalLink(ctx, ideaExternalLinksPath(idea), link)
+}
+
+// RemoveExternalLink removes an external link from an idea
+func (s *IdeasService) RemoveExternalLink(idea Ideas, linkID int) (*ExternalLinksResponse, error) {
+	return s.RemoveExternalLinkWithContext(context.Background(), idea, linkID)
+}
+
+// RemoveExternalLinkWithContext removes an external link from an idea using the given context
+func (s *IdeasService) RemoveExternalLinkWithContext(ctx context.Context, idea Ideas, linkID int) (*ExternalLinksResponse, error) {
+	return s.client.removeExternalLink(ctx, ideaExternalLinksPath(idea), linkID)
 }
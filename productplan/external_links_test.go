@@ -0,0 +1,105 @@
+package productplan
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBarsService_ListExternalLinks(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/bars/205414/external_links", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/external_links/list_external_links_success.http")
+
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	bar := Bar{ID: 205414, BarLinks: BarLinks{ExternalLinks: map[string]string{"href": "/api/bars/205414/external_links"}}}
+
+	externalLinksResponse, err := client.Bars.ListExternalLinks(bar)
+	if err != nil {
+		t.Fatalf("Bars.ListExternalLinks() returned error: %v", err)
+	}
+
+	want := ExternalLink{
+		Href:  "/api/bars/205414/external_links/3101",
+		ID:    3101,
+		URL:   "https://jira.example.com/browse/PLAN-42",
+		Title: "PLAN-42",
+	}
+
+	if len(externalLinksResponse.Items) != 2 {
+		t.Fatalf("Bars.ListExternalLinks() returned %v links, want %v", len(externalLinksResponse.Items), 2)
+	}
+	if got := externalLinksResponse.Items[0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Bars.ListExternalLinks() returned GOT: %+v, WANT %+v", got, want)
+	}
+}
+
+func TestBarsService_AddExternalLink(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/bars/205414/external_links", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/external_links/add_external_link_success.http")
+
+		testMethod(t, r, "POST")
+		testHeaders(t, r)
+
+		var got map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&got)
+		want := map[string]interface{}{"url": "https://jira.example.com/browse/PLAN-43", "title": "PLAN-43"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Request body GOT: %v, WANT %v", got, want)
+		}
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	// without links, the path is built from the ID
+	link := ExternalLink{URL: "https://jira.example.com/browse/PLAN-43", Title: "PLAN-43"}
+
+	externalLinksResponse, err := client.Bars.AddExternalLink(Bar{ID: 205414}, link)
+	if err != nil {
+		t.Fatalf("Bars.AddExternalLink() returned error: %v", err)
+	}
+
+	if externalLinksResponse.ID != 3103 || externalLinksResponse.URL != link.URL {
+		t.Errorf("Bars.AddExternalLink() returned GOT: %+v", externalLinksResponse.ExternalLink)
+	}
+}
+
+func TestIdeasService_RemoveExternalLink(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/ideas/110689/external_links/3103", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/external_links/remove_external_link_success.http")
+
+		testMethod(t, r, "DELETE")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	idea := Ideas{ID: 110689, IdeaLinks: &IdeaLinks{ExternalLinks: map[string]string{"href": "/api/ideas/110689/external_links"}}}
+
+	externalLinksResponse, err := client.Ideas.RemoveExternalLink(idea, 3103)
+	if err != nil {
+		t.Fatalf("Ideas.RemoveExternalLink() returned error: %v", err)
+	}
+
+	if externalLinksResponse.StatusCode() != 204 {
+		t.Errorf("Ideas.RemoveExternalLink() status GOT: %v", externalLinksResponse.StatusCode())
+	}
+}
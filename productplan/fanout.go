@@ -0,0 +1,148 @@
+package productplan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// defaultFanOutWorkers is the number of concurrent requests of GetBarsForRoadmaps by default.
+const defaultFanOutWorkers = 4
+
+// FanOutOptions specifies optional parameters to pass to Roadmaps.GetBarsForRoadmaps method
+type FanOutOptions struct {
+	// Workers is the maximum number of roadmaps fetched concurrently, 4 by default.
+	// Requests are still throttled by the RateLimiter of the client.
+	Workers int
+
+	// ListOptions applied to the bars of every roadmap.
+	ListOptions *ListOptions
+}
+
+// RoadmapBars is the outcome of fetching all the bars of one roadmap.
+// Err is set when the bars of the roadmap could not be fetched.
+type RoadmapBars struct {
+	Roadmap Roadmap
+	Bars    []Bar
+	Err     error
+}
+
+// GetBarsForRoadmaps fetches all the bars of the given roadmaps concurrently,
+// and sends the result of each roadmap on the returned channel as soon as it completes.
+// A failing roadmap does not stop the others, its error is reported in RoadmapBars.Err.
+// The channel is closed once every roadmap is done, and must be drained.
+func (s *RoadmapsService) GetBarsForRoadmaps(roadmaps []Roadmap, options *FanOutOptions) <-chan RoadmapBars {
+	return s.GetBarsForRoadmapsWithContext(context.Background(), roadmaps, options)
+}
+
+// GetBarsForRoadmapsWithContext fetches all the bars of the given roadmaps concurrently using the given context,
+// see GetBarsForRoadmaps.
+//
+// Cancelling ctx stops the workers and closes the channel early: cancel it when giving up
+// on the results, so that the workers do not block.
+func (s *RoadmapsService) GetBarsForRoadmapsWithContext(ctx context.Context, roadmaps []Roadmap, options *FanOutOptions) <-chan RoadmapBars {
+	workers := defaultFanOutWorkers
+	var listOptions *ListOptions
+	if options != nil {
+		if options.Workers > 0 {
+			workers = options.Workers
+		}
+		listOptions = options.ListOptions
+	}
+	if workers > len(roadmaps) {
+		workers = len(roadmaps)
+	}
+
+	jobs := make(chan Roadmap)
+	results := make(chan RoadmapBars)
+
+	go func() {
+		defer close(jobs)
+		for _, roadmap := range roadmaps {
+			select {
+			case jobs <- roadmap:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for roadmap := range jobs {
+				bars, err := ListAll(s.IterBars(ctx, roadmap, listOptions), 0)
+				if err != nil {
+					err = fmt.Errorf("roadmap %v: %w", roadmap.ID, err)
+				}
+
+				select {
+				case results <- RoadmapBars{Roadmap: roadmap, Bars: bars, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// CollectRoadmapBars drains the results of GetBarsForRoadmaps, and returns the bars by roadmap ID.
+// The bars of the successful roadmaps are returned even when others failed, along with a *FanOutError.
+func CollectRoadmapBars(results <-chan RoadmapBars) (map[int][]Bar, error) {
+	bars := make(map[int][]Bar)
+	fanOutError := &FanOutError{Errors: make(map[int]error)}
+
+	for result := range results {
+		if result.Err != nil {
+			fanOutError.Errors[result.Roadmap.ID] = result.Err
+			continue
+		}
+		bars[result.Roadmap.ID] = result.Bars
+	}
+
+	if len(fanOutError.Errors) > 0 {
+		return bars, fanOutError
+	}
+	return bars, nil
+}
+
+// FanOutError aggregates the errors of the roadmaps that failed, by roadmap ID.
+// errors.Is and errors.As match any of them.
+type FanOutError struct {
+	Errors map[int]error
+}
+
+// Error implements the error interface.
+func (e *FanOutError) Error() string {
+	ids := make([]int, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	messages := make([]string, 0, len(ids))
+	for _, id := range ids {
+		messages = append(messages, e.Errors[id].Error())
+	}
+	return fmt.Sprintf("productplan: %v roadmaps failed: %v", len(ids), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the roadmaps.
+func (e *FanOutError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
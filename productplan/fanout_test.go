@@ -0,0 +1,111 @@
+package productplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// setupRoadmapBars serves one bar per roadmap, with ID 100 + the roadmap ID, and 404 for roadmap 3.
+// It returns the highest number of concurrent requests seen.
+func setupRoadmapBars(t *testing.T, roadmaps int) func() int {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	for id := 1; id <= roadmaps; id++ {
+		id := id
+		mux.HandleFunc(fmt.Sprintf("/api/roadmaps/%v/bars", id), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+
+			if id == 3 {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"message":"Roadmap not found"}`)
+				return
+			}
+			fmt.Fprintf(w, `[{"id":%d,"name":"Bar"}]`, 100+id)
+		})
+	}
+
+	return func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return maxInFlight
+	}
+}
+
+func testRoadmaps(n int) []Roadmap {
+	var roadmaps []Roadmap
+	for id := 1; id <= n; id++ {
+		roadmaps = append(roadmaps, Roadmap{ID: id})
+	}
+	return roadmaps
+}
+
+func TestRoadmapsService_GetBarsForRoadmaps(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	maxInFlight := setupRoadmapBars(t, 8)
+
+	results := client.Roadmaps.GetBarsForRoadmaps(testRoadmaps(8), &FanOutOptions{Workers: 3})
+	bars, err := CollectRoadmapBars(results)
+
+	var fanOutError *FanOutError
+	if !errors.As(err, &fanOutError) {
+		t.Fatalf("CollectRoadmapBars() error = %v, want a *FanOutError", err)
+	}
+	if len(fanOutError.Errors) != 1 || fanOutError.Errors[3] == nil {
+		t.Errorf("FanOutError.Errors = %v, want roadmap 3 only", fanOutError.Errors)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("CollectRoadmapBars() error %v should match %v", err, ErrNotFound)
+	}
+
+	if len(bars) != 7 {
+		t.Errorf("CollectRoadmapBars() returned bars of %v roadmaps, want %v", len(bars), 7)
+	}
+	if want := []int{108}; !reflect.DeepEqual(barIDs(bars[8]), want) {
+		t.Errorf("bars of roadmap 8 = %v, want %v", barIDs(bars[8]), want)
+	}
+
+	if n := maxInFlight(); n > 3 || n < 2 {
+		t.Errorf("sent up to %v concurrent requests, want 3 workers", n)
+	}
+}
+
+func TestRoadmapsService_GetBarsForRoadmaps_Canceled(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	setupRoadmapBars(t, 20)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := client.Roadmaps.GetBarsForRoadmapsWithContext(ctx, testRoadmaps(20), &FanOutOptions{Workers: 2})
+
+	<-results
+	cancel()
+
+	received := 1
+	for range results {
+		received++
+	}
+	if received >= 20 {
+		t.Errorf("received %v results after cancelling, want the fan-out to stop early", received)
+	}
+}
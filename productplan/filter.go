@@ -0,0 +1,360 @@
+package productplan
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field names a field that lists can be filtered and ordered on.
+type Field string
+
+// Fields of the roadmaps, bars and ideas, see the Resource for the fields each one supports.
+const (
+	FieldID             Field = "id"
+	FieldName           Field = "name"
+	FieldDescription    Field = "description"
+	FieldOwnerEmail     Field = "owner_email"
+	FieldStrategicValue Field = "strategic_value"
+	FieldTags           Field = "tags"
+	FieldStartDate      Field = "start_date"
+	FieldEndDate        Field = "end_date"
+	FieldPercentDone    Field = "percent_done"
+	FieldEffort         Field = "effort"
+	FieldCreatedAt      Field = "created_at"
+	FieldUpdatedAt      Field = "updated_at"
+)
+
+// fieldKind tells the comparisons a field supports, and how its values are rendered.
+type fieldKind int
+
+const (
+	kindText      fieldKind = iota // equality and wildcards
+	kindNumber                     // equality
+	kindDate                       // equality and date comparisons, as YYYY-MM-DD
+	kindTimestamp                  // equality and date comparisons, as RFC 3339
+)
+
+// Resource names an endpoint listing roadmaps, bars or ideas.
+type Resource string
+
+// Resources that can be listed with a Filter and an Order.
+const (
+	ResourceRoadmaps Resource = "roadmaps"
+	ResourceBars     Resource = "bars"
+	ResourceIdeas    Resource = "ideas"
+)
+
+// resourceFields lists the fields supported by the filters and the order of each resource.
+var resourceFields = map[Resource]map[Field]fieldKind{
+	ResourceRoadmaps: {
+		FieldID:          kindNumber,
+		FieldName:        kindText,
+		FieldDescription: kindText,
+		FieldOwnerEmail:  kindText,
+		FieldCreatedAt:   kindTimestamp,
+		FieldUpdatedAt:   kindTimestamp,
+	},
+	ResourceBars: {
+		FieldID:             kindNumber,
+		FieldName:           kindText,
+		FieldDescription:    kindText,
+		FieldStrategicValue: kindText,
+		FieldTags:           kindText,
+		FieldStartDate:      kindDate,
+		FieldEndDate:        kindDate,
+		FieldPercentDone:    kindNumber,
+		FieldEffort:         kindNumber,
+		FieldCreatedAt:      kindTimestamp,
+		FieldUpdatedAt:      kindTimestamp,
+	},
+	ResourceIdeas: {
+		FieldID:             kindNumber,
+		FieldName:           kindText,
+		FieldDescription:    kindText,
+		FieldStrategicValue: kindText,
+		FieldTags:           kindText,
+		FieldEffort:         kindNumber,
+		FieldCreatedAt:      kindTimestamp,
+		FieldUpdatedAt:      kindTimestamp,
+	},
+}
+
+// field returns the kind of a field of the resource, or an error if the resource does not support it.
+func (r Resource) field(field Field) (fieldKind, error) {
+	fields, ok := resourceFields[r]
+	if !ok {
+		return 0, fmt.Errorf("productplan: unknown resource %q", r)
+	}
+	kind, ok := fields[field]
+	if !ok {
+		return 0, fmt.Errorf("productplan: %v cannot be filtered or ordered by %q", r, field)
+	}
+	return kind, nil
+}
+
+// Operator compares a field with the value of a Condition.
+type Operator string
+
+// Operators of the filter expressions.
+const (
+	OpEqual          Operator = "="
+	OpLess           Operator = "<"
+	OpLessOrEqual    Operator = "<="
+	OpGreater        Operator = ">"
+	OpGreaterOrEqual Operator = ">="
+)
+
+// Condition is a single comparison of a Filter. Build them with Eq, Match, Prefix,
+// Before, After, Since and Until rather than directly.
+type Condition struct {
+	Field    Field
+	Operator Operator
+
+	// value is the rendered value, already escaped
+	value string
+
+	// raw is the value of Eq, rendered according to the kind of the field
+	raw interface{}
+	eq  bool
+
+	// wildcard tells the value holds * wildcards
+	wildcard bool
+
+	// date tells the value is time, rendered according to the kind of the field
+	date bool
+	time time.Time
+}
+
+// filterEscaper escapes the characters having a meaning in the filter expressions.
+var filterEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `,`, `\,`)
+
+// Eq matches the entries whose field is exactly value. Wildcard characters in value are escaped.
+// The value must match the kind of the field: a string for text fields, an integer, a float or
+// a numeric string for number fields, and a time.Time or a YYYY-MM-DD string for dates,
+// or an RFC 3339 string for timestamps.
+func Eq(field Field, value interface{}) Condition {
+	return Condition{Field: field, Operator: OpEqual, raw: value, eq: true}
+}
+
+// Match matches the entries whose field matches the pattern, where * matches any characters,
+// eg. Match(FieldName, "Planning*2024"). Characters other than * are escaped.
+func Match(field Field, pattern string) Condition {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = filterEscaper.Replace(part)
+	}
+	return Condition{Field: field, Operator: OpEqual, value: strings.Join(parts, "*"), wildcard: true}
+}
+
+// Prefix matches the entries whose field starts with prefix.
+func Prefix(field Field, prefix string) Condition {
+	return Condition{Field: field, Operator: OpEqual, value: filterEscaper.Replace(prefix) + "*", wildcard: true}
+}
+
+// Before matches the entries whose date field is strictly before t.
+func Before(field Field, t time.Time) Condition {
+	return Condition{Field: field, Operator: OpLess, date: true, time: t}
+}
+
+// Until matches the entries whose date field is at or before t.
+func Until(field Field, t time.Time) Condition {
+	return Condition{Field: field, Operator: OpLessOrEqual, date: true, time: t}
+}
+
+// After matches the entries whose date field is strictly after t.
+func After(field Field, t time.Time) Condition {
+	return Condition{Field: field, Operator: OpGreater, date: true, time: t}
+}
+
+// Since matches the entries whose date field is at or after t.
+func Since(field Field, t time.Time) Condition {
+	return Condition{Field: field, Operator: OpGreaterOrEqual, date: true, time: t}
+}
+
+// render returns the condition in the filter syntax, for a field of the given kind.
+func (c Condition) render(kind fieldKind) (string, error) {
+	value := c.value
+	switch {
+	case c.date:
+		value = formatFilterTime(kind, c.time)
+	case c.eq:
+		var err error
+		if value, err = formatFilterValue(kind, c.raw); err != nil {
+			return "", err
+		}
+	}
+	return string(c.Field) + string(c.Operator) + value, nil
+}
+
+// formatFilterTime renders a time as a date or as a timestamp, according to the kind of the field.
+func formatFilterTime(kind fieldKind, t time.Time) string {
+	if kind == kindDate {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
+
+// formatFilterValue renders the value of Eq for a field of the given kind,
+// or returns an error if the value does not match the kind.
+func formatFilterValue(kind fieldKind, value interface{}) (string, error) {
+	switch kind {
+	case kindText:
+		if s, ok := value.(string); ok {
+			return filterEscaper.Replace(s), nil
+		}
+		return "", fmt.Errorf("%v (%T) is not a string", value, value)
+
+	case kindNumber:
+		switch n := value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return fmt.Sprint(n), nil
+		case float32:
+			return strconv.FormatFloat(float64(n), 'f', -1, 32), nil
+		case float64:
+			return strconv.FormatFloat(n, 'f', -1, 64), nil
+		case string:
+			if _, err := strconv.ParseFloat(n, 64); err != nil {
+				return "", fmt.Errorf("%q is not a number", n)
+			}
+			return n, nil
+		}
+		return "", fmt.Errorf("%v (%T) is not a number", value, value)
+
+	case kindDate, kindTimestamp:
+		switch t := value.(type) {
+		case time.Time:
+			return formatFilterTime(kind, t), nil
+		case string:
+			if _, err := time.Parse("2006-01-02", t); err == nil {
+				return t, nil
+			}
+			if _, err := time.Parse(time.RFC3339, t); err == nil && kind == kindTimestamp {
+				return t, nil
+			}
+			if kind == kindDate {
+				return "", fmt.Errorf("%q is not a YYYY-MM-DD date", t)
+			}
+			return "", fmt.Errorf("%q is not an RFC 3339 timestamp nor a YYYY-MM-DD date", t)
+		}
+		return "", fmt.Errorf("%v (%T) is not a date", value, value)
+	}
+	return "", fmt.Errorf("unsupported value %v", value)
+}
+
+// validate checks that the resource supports the condition.
+func (c Condition) validate(resource Resource) (fieldKind, error) {
+	kind, err := resource.field(c.Field)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case c.wildcard && kind != kindText:
+		return 0, fmt.Errorf("productplan: %v %v does not support wildcards", resource, c.Field)
+	case c.date && kind != kindDate && kind != kindTimestamp:
+		return 0, fmt.Errorf("productplan: %v %v is not a date", resource, c.Field)
+	}
+	return kind, nil
+}
+
+// Filter is a list of conditions that must all match, rendered to the syntax of ListOptions.Filters:
+// comma-separated field<operator>value conditions, where * is a wildcard and \ escapes the next
+// character, eg. name=Planning*,start_date>=2024-01-01
+type Filter []Condition
+
+// NewFilter returns a Filter matching all the conditions.
+func NewFilter(conditions ...Condition) Filter {
+	return Filter(conditions)
+}
+
+// And returns a Filter matching the conditions of f, and the given ones too.
+func (f Filter) And(conditions ...Condition) Filter {
+	combined := make(Filter, 0, len(f)+len(conditions))
+	return append(append(combined, f...), conditions...)
+}
+
+// Build validates the filter against the fields of resource, and renders it.
+func (f Filter) Build(resource Resource) (string, error) {
+	conditions := make([]string, 0, len(f))
+	for _, condition := range f {
+		kind, err := condition.validate(resource)
+		if err != nil {
+			return "", err
+		}
+
+		rendered, err := condition.render(kind)
+		if err != nil {
+			return "", fmt.Errorf("productplan: %v %v: %w", resource, condition.Field, err)
+		}
+		conditions = append(conditions, rendered)
+	}
+	return strings.Join(conditions, ","), nil
+}
+
+// Direction is the direction of an Order.
+type Direction string
+
+// Directions of an Order.
+const (
+	Ascending  Direction = "asc"
+	Descending Direction = "desc"
+)
+
+// Order is a sort criterion of a list. Use several of them to break ties.
+type Order struct {
+	Field     Field
+	Direction Direction
+}
+
+// Asc orders by field, in ascending order.
+func Asc(field Field) Order {
+	return Order{Field: field, Direction: Ascending}
+}
+
+// Desc orders by field, in descending order.
+func Desc(field Field) Order {
+	return Order{Field: field, Direction: Descending}
+}
+
+// BuildOrder validates the orders against the fields of resource,
+// and renders them to the syntax of ListOptions.Order, eg. name:asc,end_date:desc
+func BuildOrder(resource Resource, orders ...Order) (string, error) {
+	criteria := make([]string, 0, len(orders))
+	for _, order := range orders {
+		if _, err := resource.field(order.Field); err != nil {
+			return "", err
+		}
+
+		switch order.Direction {
+		case "":
+			criteria = append(criteria, string(order.Field))
+		case Ascending, Descending:
+			criteria = append(criteria, string(order.Field)+":"+string(order.Direction))
+		default:
+			return "", fmt.Errorf("productplan: invalid order direction %q", order.Direction)
+		}
+	}
+	return strings.Join(criteria, ","), nil
+}
+
+// SetFilter validates the filter against the fields of resource, and sets the Filters.
+func (o *ListOptions) SetFilter(resource Resource, filter Filter) error {
+	filters, err := filter.Build(resource)
+	if err != nil {
+		return err
+	}
+	o.Filters = filters
+	return nil
+}
+
+// SetOrder validates the orders against the fields of resource, and sets the Order.
+func (o *ListOptions) SetOrder(resource Resource, orders ...Order) error {
+	order, err := BuildOrder(resource, orders...)
+	if err != nil {
+		return err
+	}
+	o.Order = order
+	return nil
+}
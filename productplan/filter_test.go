@@ -0,0 +1,115 @@
+package productplan
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFilter_Build(t *testing.T) {
+	date := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		resource Resource
+		filter   Filter
+		want     string
+	}{
+		{ResourceRoadmaps, NewFilter(Eq(FieldName, "Planning Roadmap")), "name=Planning Roadmap"},
+		{ResourceRoadmaps, NewFilter(Prefix(FieldName, "Planning")), "name=Planning*"},
+		{ResourceRoadmaps, NewFilter(Match(FieldName, "Q1*, 2024")), `name=Q1*\, 2024`},
+		{ResourceBars, NewFilter(Eq(FieldName, `50% *done*, or \ not`)), `name=50% \*done\*\, or \\ not`},
+		{ResourceBars, NewFilter(Eq(FieldEffort, 5)), "effort=5"},
+		{ResourceBars, NewFilter(Eq(FieldPercentDone, 12.5), Eq(FieldID, "205400")), "percent_done=12.5,id=205400"},
+		{ResourceBars, NewFilter(Eq(FieldStartDate, date), Eq(FieldEndDate, "2024-06-30")), "start_date=2024-03-01,end_date=2024-06-30"},
+		{ResourceIdeas, NewFilter(Eq(FieldCreatedAt, date), Eq(FieldUpdatedAt, "2024-03-01")), "created_at=2024-03-01T09:30:00Z,updated_at=2024-03-01"},
+		{ResourceBars, NewFilter(Since(FieldStartDate, date), Before(FieldEndDate, date)), "start_date>=2024-03-01,end_date<2024-03-01"},
+		{ResourceIdeas, NewFilter(After(FieldUpdatedAt, date)).And(Until(FieldCreatedAt, date)), "updated_at>2024-03-01T09:30:00Z,created_at<=2024-03-01T09:30:00Z"},
+		{ResourceIdeas, nil, ""},
+	}
+
+	for _, test := range tests {
+		got, err := test.filter.Build(test.resource)
+		if err != nil {
+			t.Errorf("Build(%v) returned error: %v", test.resource, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Build(%v) = %q, want %q", test.resource, got, test.want)
+		}
+	}
+}
+
+func TestFilter_Build_Invalid(t *testing.T) {
+	tests := []struct {
+		resource Resource
+		filter   Filter
+	}{
+		{ResourceRoadmaps, NewFilter(Eq("nmae", "Planning"))},
+		{ResourceRoadmaps, NewFilter(Eq(FieldStartDate, "2024-01-01"))},
+		{ResourceBars, NewFilter(Prefix(FieldEffort, "1"))},
+		{ResourceBars, NewFilter(Eq(FieldEffort, "five"))},
+		{ResourceBars, NewFilter(Eq(FieldName, 5))},
+		{ResourceBars, NewFilter(Eq(FieldStartDate, "03/01/2024"))},
+		{ResourceBars, NewFilter(Eq(FieldStartDate, "2024-03-01T09:30:00Z"))},
+		{ResourceBars, NewFilter(Eq(FieldEndDate, 20240301))},
+		{ResourceIdeas, NewFilter(Eq(FieldCreatedAt, time.Now().String()))},
+		{ResourceBars, NewFilter(Since(FieldName, time.Now()))},
+		{"lanes", NewFilter(Eq(FieldName, "Lane"))},
+	}
+
+	for _, test := range tests {
+		if got, err := test.filter.Build(test.resource); err == nil {
+			t.Errorf("Build(%v) = %q, want an error", test.resource, got)
+		}
+	}
+}
+
+func TestBuildOrder(t *testing.T) {
+	got, err := BuildOrder(ResourceBars, Desc(FieldEndDate), Asc(FieldName), Order{Field: FieldID})
+	if err != nil {
+		t.Fatalf("BuildOrder() returned error: %v", err)
+	}
+	if want := "end_date:desc,name:asc,id"; got != want {
+		t.Errorf("BuildOrder() = %q, want %q", got, want)
+	}
+
+	if _, err := BuildOrder(ResourceRoadmaps, Desc(FieldEndDate)); err == nil {
+		t.Errorf("BuildOrder() accepted a field roadmaps do not support")
+	}
+	if _, err := BuildOrder(ResourceBars, Order{Field: FieldName, Direction: "up"}); err == nil {
+		t.Errorf("BuildOrder() accepted an invalid direction")
+	}
+}
+
+func TestListOptions_SetFilter(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/bars", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("filters"), `name=API\,Bar*`; got != want {
+			t.Errorf("filters = %q, want %q", got, want)
+		}
+		if got, want := r.URL.Query().Get("order"), "start_date:desc"; got != want {
+			t.Errorf("order = %q, want %q", got, want)
+		}
+		w.Write([]byte("[]"))
+	})
+
+	options := &ListOptions{}
+	if err := options.SetFilter(ResourceBars, NewFilter(Prefix(FieldName, "API,Bar"))); err != nil {
+		t.Fatalf("SetFilter() returned error: %v", err)
+	}
+	if err := options.SetOrder(ResourceBars, Desc(FieldStartDate)); err != nil {
+		t.Fatalf("SetOrder() returned error: %v", err)
+	}
+
+	if err := options.SetFilter(ResourceBars, NewFilter(Eq(FieldOwnerEmail, "x"))); err == nil || !strings.Contains(err.Error(), "owner_email") {
+		t.Errorf("SetFilter() error = %v, want the unsupported field", err)
+	}
+
+	if _, err := client.Bars.ListBars(options); err != nil {
+		t.Fatalf("Bars.ListBars() returned error: %v", err)
+	}
+}
@@ -1,7 +1,9 @@
 package productplan
 
 import (
+	"context"
 	"fmt"
+	"io"
 )
 
 // IdeasService handles communication with the idea
@@ -32,6 +34,18 @@ type IdeaLinks struct {
 	ExternalLinks map[string]string `json:"external_links"`
 }
 
+// UpdateIdea idea attributes to perform an update
+type UpdateIdea struct {
+	Name           string            `json:"name,omitempty"`
+	Description    string            `json:"description,omitempty"`
+	StrategicValue string            `json:"strategic_value,omitempty"`
+	Notes          string            `json:"notes,omitempty"`
+	PercentDone    int               `json:"percent_done,omitempty"`
+	Effort         int               `json:"effort,omitempty"`
+	Tags           []string          `json:"tags,omitempty"`
+	Fields         map[string]string `json:"fields,omitempty"`
+}
+
 // IdeasResponse represents a response from an API method that returns an Ideas struct.
 type IdeasResponse struct {
 	Response
@@ -40,16 +54,79 @@ type IdeasResponse struct {
 
 // Show an idea
 func (s *IdeasService) Show(id string) (*IdeasResponse, error) {
+	return s.ShowWithContext(context.Background(), id)
+}
+
+// ShowWithContext shows an idea using the given context
+func (s *IdeasService) ShowWithContext(ctx context.Context, id string) (*IdeasResponse, error) {
 	path := fmt.Sprintf("/api/ideas/%v", id)
 	ideasResponse := &IdeasResponse{}
 
-	resp, err := s.client.get(path, ideasResponse)
+	resp, err := s.client.get(ctx, path, ideasResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	ideasResponse.HTTPResponse = resp
+	ideasResponse.Response = newResponse(resp)
+	return ideasResponse, nil
+}
+
+// ListIdeas get a list of ideas
+func (s *IdeasService) ListIdeas(options *ListOptions) (*ListResponse[Ideas], error) {
+	return s.ListIdeasWithContext(context.Background(), options)
+}
+
+// ListIdeasWithContext get a page of ideas using the given context.
+// Use options.Cursor to select the page to return.
+func (s *IdeasService) ListIdeasWithContext(ctx context.Context, options *ListOptions) (*ListResponse[Ideas], error) {
+	return getList[Ideas](ctx, s.client, "/api/ideas", options)
+}
+
+// IterIdeas iterates over all the ideas, fetching the pages as needed.
+func (s *IdeasService) IterIdeas(ctx context.Context, options *ListOptions) *Iterator[Ideas] {
+	return newIterator(ctx, func(ctx context.Context, cursor *Cursor) (*ListResponse[Ideas], error) {
+		return s.ListIdeasWithContext(ctx, withCursor(options, cursor))
+	})
+}
+
+// UpdateIdea updates an idea
+func (s *IdeasService) UpdateIdea(id int, ideaAttributes interface{}) (*IdeasResponse, error) {
+	return s.UpdateIdeaWithContext(context.Background(), id, ideaAttributes)
+}
+
+// UpdateIdeaWithContext updates an idea using the given context
+func (s *IdeasService) UpdateIdeaWithContext(ctx context.Context, id int, ideaAttributes interface{}) (*IdeasResponse, error) {
+	path := fmt.Sprintf("/api/ideas/%v", id)
+	ideasResponse := &IdeasResponse{}
+
+	resp, err := s.client.patch(ctx, path, ideaAttributes, ideasResponse)
+
+	// update does not return any response data, so expect the response decode to be EOF
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	ideasResponse.Response = newResponse(resp)
 	return ideasResponse, nil
 }
 
-// TODO: index show all ideas paginated
+// DeleteIdea deletes an idea
+func (s *IdeasService) DeleteIdea(id int) (*IdeasResponse, error) {
+	return s.DeleteIdeaWithContext(context.Background(), id)
+}
+
+// DeleteIdeaWithContext deletes an idea using the given context
+func (s *IdeasService) DeleteIdeaWithContext(ctx context.Context, id int) (*IdeasResponse, error) {
+	path := fmt.Sprintf("/api/ideas/%v", id)
+	ideasResponse := &IdeasResponse{}
+
+	resp, err := s.client.delete(ctx, path, ideasResponse)
+
+	// delete does not return any response data, so expect the response decode to be EOF
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	ideasResponse.Response = newResponse(resp)
+	return ideasResponse, nil
+}
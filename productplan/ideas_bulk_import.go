@@ -0,0 +1,240 @@
+package productplan
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Defaults of the BulkImportOptions.
+const (
+	defaultImportBatchSize = 50
+	defaultImportWorkers   = 2
+)
+
+// BulkImportOptions specifies optional parameters to pass to Ideas.BulkImport method
+type BulkImportOptions struct {
+	// BatchSize is the number of ideas per import request, 50 by default.
+	BatchSize int
+
+	// Workers is the maximum number of import requests sent concurrently, 2 by default.
+	Workers int
+
+	// CheckpointFile records the batches imported successfully. When set, a run
+	// resumed with the same ideas and batch size skips the batches already imported.
+	CheckpointFile string
+}
+
+// IdeaImportResult is the outcome of importing one idea.
+type IdeaImportResult struct {
+	// Index of the idea in the imported list.
+	Index int
+	Idea  Ideas
+
+	// Batch is the index of the batch the idea was sent in.
+	Batch int
+
+	// Skipped is set when the batch was imported by a previous run, according to the checkpoint.
+	Skipped bool
+
+	// Err is the error of the batch, nil if the idea was imported.
+	Err error
+}
+
+// BulkImportReport is the outcome of a bulk import, with one result per idea, in order.
+type BulkImportReport struct {
+	Results []IdeaImportResult
+}
+
+// Failed returns the results of the ideas that were not imported.
+func (r *BulkImportReport) Failed() []IdeaImportResult {
+	var failed []IdeaImportResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns an error summarizing the failures, or nil if every idea was imported.
+func (r *BulkImportReport) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("productplan: %v of %v ideas not imported, first error: %w", len(failed), len(r.Results), failed[0].Err)
+}
+
+// importCheckpoint is the content of a checkpoint file.
+type importCheckpoint struct {
+	// Fingerprint identifies the import, so that a checkpoint is never applied to other ideas.
+	Fingerprint string `json:"fingerprint"`
+	Completed   []int  `json:"completed"`
+
+	path string
+	mu   sync.Mutex
+	done map[int]bool
+}
+
+// importFingerprint identifies an import by its roadmap, ideas and batch size.
+func importFingerprint(roadmapID int, ideas []Ideas, batchSize int) (string, error) {
+	data, err := json.Marshal(ideas)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v/%v/%s", roadmapID, batchSize, data)))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// loadCheckpoint reads the checkpoint at path, or starts a new one if there is none.
+func loadCheckpoint(path, fingerprint string) (*importCheckpoint, error) {
+	checkpoint := &importCheckpoint{Fingerprint: fingerprint, path: path, done: make(map[int]bool)}
+	if path == "" {
+		return checkpoint, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	saved := &importCheckpoint{}
+	if err := json.Unmarshal(data, saved); err != nil {
+		return nil, fmt.Errorf("productplan: reading import checkpoint %v: %w", path, err)
+	}
+	if saved.Fingerprint != fingerprint {
+		return nil, fmt.Errorf("productplan: import checkpoint %v belongs to another import", path)
+	}
+
+	for _, batch := range saved.Completed {
+		checkpoint.done[batch] = true
+	}
+	checkpoint.Completed = saved.Completed
+	return checkpoint, nil
+}
+
+// isDone reports whether a batch was imported by a previous run.
+func (c *importCheckpoint) isDone(batch int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done[batch]
+}
+
+// complete records an imported batch, and saves the checkpoint.
+func (c *importCheckpoint) complete(batch int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.done[batch] = true
+	c.Completed = append(c.Completed, batch)
+	sort.Ints(c.Completed)
+	if c.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	// write then rename, so that a crash never leaves a partial checkpoint
+	if err := ioutil.WriteFile(c.path+".tmp", data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(c.path+".tmp", c.path)
+}
+
+// BulkImport imports ideas onto a roadmap in batches, sent concurrently.
+//
+// A failed batch does not stop the others: the report tells, for each idea, whether it was imported
+// or the error of its batch. The returned error is only set when the import could not start,
+// eg. when the checkpoint file belongs to another import.
+func (s *IdeasService) BulkImport(roadmapID int, ideas []Ideas, options *BulkImportOptions) (*BulkImportReport, error) {
+	return s.BulkImportWithContext(context.Background(), roadmapID, ideas, options)
+}
+
+// BulkImportWithContext imports ideas onto a roadmap in batches using the given context, see BulkImport.
+func (s *IdeasService) BulkImportWithContext(ctx context.Context, roadmapID int, ideas []Ideas, options *BulkImportOptions) (*BulkImportReport, error) {
+	batchSize, workers, checkpointFile := defaultImportBatchSize, defaultImportWorkers, ""
+	if options != nil {
+		if options.BatchSize > 0 {
+			batchSize = options.BatchSize
+		}
+		if options.Workers > 0 {
+			workers = options.Workers
+		}
+		checkpointFile = options.CheckpointFile
+	}
+
+	fingerprint, err := importFingerprint(roadmapID, ideas, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := loadCheckpoint(checkpointFile, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BulkImportReport{Results: make([]IdeaImportResult, len(ideas))}
+	for i, idea := range ideas {
+		report.Results[i] = IdeaImportResult{Index: i, Idea: idea, Batch: i / batchSize}
+	}
+
+	batches := (len(ideas) + batchSize - 1) / batchSize
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for batch := 0; batch < batches; batch++ {
+			jobs <- batch
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i < batches; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range jobs {
+				start, end := batch*batchSize, (batch+1)*batchSize
+				if end > len(ideas) {
+					end = len(ideas)
+				}
+
+				skipped := checkpoint.isDone(batch)
+				var err error
+				if !skipped {
+					_, err = s.ImportWithContext(ctx, IdeasImportAttributes{
+						IdeaImportRoadmap: IdeaImportRoadmap{ID: roadmapID},
+						Ideas:             ideas[start:end],
+					})
+					if err == nil {
+						if saveErr := checkpoint.complete(batch); saveErr != nil {
+							err = fmt.Errorf("productplan: batch %v imported, saving the checkpoint: %w", batch, saveErr)
+						}
+					}
+				}
+
+				// each batch owns its range of results
+				for i := start; i < end; i++ {
+					report.Results[i].Skipped = skipped
+					report.Results[i].Err = err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report, nil
+}
@@ -0,0 +1,140 @@
+package productplan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func testIdeas(n int) []Ideas {
+	var ideas []Ideas
+	for i := 0; i < n; i++ {
+		ideas = append(ideas, Ideas{Name: fmt.Sprintf("Idea%d", i)})
+	}
+	return ideas
+}
+
+// setupBulkImport serves the import endpoint, failing the batches containing an idea named failing.
+// It returns the first idea name of each batch received.
+func setupBulkImport(t *testing.T, failing string) func() []string {
+	var mu sync.Mutex
+	var batches []string
+
+	mux.HandleFunc("/api/ideas/actions/import", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		attributes := IdeasImportAttributes{}
+		json.NewDecoder(r.Body).Decode(&attributes)
+		if attributes.IdeaImportRoadmap.ID != 5912 {
+			t.Errorf("imported onto roadmap %v, want %v", attributes.IdeaImportRoadmap.ID, 5912)
+		}
+
+		mu.Lock()
+		batches = append(batches, attributes.Ideas[0].Name)
+		mu.Unlock()
+
+		for _, idea := range attributes.Ideas {
+			if idea.Name == failing {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				io.WriteString(w, `{"message":"Validation failed","errors":{"name":["is invalid"]}}`)
+				return
+			}
+		}
+
+		httpResponse := httpResponseFixture(t, "/ideas_import/idea_import_success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		sorted := append([]string(nil), batches...)
+		sort.Strings(sorted)
+		return sorted
+	}
+}
+
+func TestIdeasService_BulkImport(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	batches := setupBulkImport(t, "Idea4")
+
+	report, err := client.Ideas.BulkImportWithContext(context.Background(), 5912, testIdeas(7), &BulkImportOptions{BatchSize: 3, Workers: 2})
+	if err != nil {
+		t.Fatalf("Ideas.BulkImport() returned error: %v", err)
+	}
+
+	if got, want := batches(), []string{"Idea0", "Idea3", "Idea6"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("batches sent %v, want %v", got, want)
+	}
+
+	if len(report.Results) != 7 {
+		t.Fatalf("report has %v results, want %v", len(report.Results), 7)
+	}
+	for i, result := range report.Results {
+		failed := i >= 3 && i < 6
+		if result.Index != i || result.Idea.Name != fmt.Sprintf("Idea%d", i) || result.Batch != i/3 {
+			t.Errorf("result %v = %+v", i, result)
+		}
+		if failed != (result.Err != nil) {
+			t.Errorf("result %v error = %v, want failed=%v", i, result.Err, failed)
+		}
+	}
+
+	if !errors.Is(report.Err(), ErrValidation) || len(report.Failed()) != 3 {
+		t.Errorf("report.Err() = %v with %v failures, want 3 validation failures", report.Err(), len(report.Failed()))
+	}
+}
+
+func TestIdeasService_BulkImport_Resume(t *testing.T) {
+	checkpointFile := filepath.Join(t.TempDir(), "import.json")
+	options := &BulkImportOptions{BatchSize: 2, Workers: 3, CheckpointFile: checkpointFile}
+	ideas := testIdeas(6)
+
+	// the first run fails the second batch
+	setupMockServer()
+	setupBulkImport(t, "Idea2")
+	report, err := client.Ideas.BulkImport(5912, ideas, options)
+	teardownMockServer()
+	if err != nil {
+		t.Fatalf("Ideas.BulkImport() returned error: %v", err)
+	}
+	if len(report.Failed()) != 2 {
+		t.Fatalf("first run failed %v ideas, want %v", len(report.Failed()), 2)
+	}
+
+	// the resumed run only sends the failed batch
+	setupMockServer()
+	defer teardownMockServer()
+	batches := setupBulkImport(t, "")
+
+	report, err = client.Ideas.BulkImport(5912, ideas, options)
+	if err != nil {
+		t.Fatalf("Ideas.BulkImport() returned error: %v", err)
+	}
+	if got, want := batches(), []string{"Idea2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("resumed run sent batches %v, want %v", got, want)
+	}
+	if err := report.Err(); err != nil {
+		t.Errorf("report.Err() = %v", err)
+	}
+	if !report.Results[0].Skipped || report.Results[2].Skipped {
+		t.Errorf("Skipped results = %v, %v, want true, false", report.Results[0].Skipped, report.Results[2].Skipped)
+	}
+
+	// a checkpoint is never applied to other ideas
+	_, err = client.Ideas.BulkImport(5912, testIdeas(5), options)
+	if err == nil || !strings.Contains(err.Error(), "another import") {
+		t.Errorf("Ideas.BulkImport() error = %v, want a checkpoint mismatch", err)
+	}
+}
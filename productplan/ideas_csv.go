@@ -0,0 +1,328 @@
+package productplan
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVMapping maps the column headers of a CSV file to the attributes of the ideas.
+// Headers are matched case-insensitively. The columns not mapped to an attribute,
+// nor ignored, go to the Fields of the ideas under their header, eg. pp_lanes.
+type CSVMapping struct {
+	Name           string
+	Description    string
+	StrategicValue string
+	Notes          string
+	PercentDone    string
+	Effort         string
+	Tags           string
+
+	// TagSeparator splits the tags column, "," by default.
+	TagSeparator string
+
+	// Fields renames columns going to the Fields, eg. {"Lane": "pp_lanes"}.
+	Fields map[string]string
+
+	// Ignore lists the columns to leave out.
+	Ignore []string
+}
+
+// DefaultCSVMapping returns the mapping of the columns named after the JSON attributes,
+// eg. name, strategic_value and percent_done.
+func DefaultCSVMapping() *CSVMapping {
+	return &CSVMapping{
+		Name:           "name",
+		Description:    "description",
+		StrategicValue: "strategic_value",
+		Notes:          "notes",
+		PercentDone:    "percent_done",
+		Effort:         "effort",
+		Tags:           "tags",
+		TagSeparator:   ",",
+	}
+}
+
+// CSVOptions specifies optional parameters to read ideas from a CSV file
+type CSVOptions struct {
+	// Mapping of the columns, DefaultCSVMapping when nil.
+	Mapping *CSVMapping
+
+	// Comma is the field delimiter, ',' by default, eg. '\t' for spreadsheet exports or ';'.
+	Comma rune
+}
+
+// CSVRowError is the error of a CSV row, with its line number in the file.
+type CSVRowError struct {
+	Line   int
+	Column string
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *CSVRowError) Error() string {
+	if e.Column != "" {
+		return fmt.Sprintf("line %v: %v: %v", e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("line %v: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *CSVRowError) Unwrap() error {
+	return e.Err
+}
+
+// CSVErrors lists the errors of every invalid row of a CSV file.
+type CSVErrors []*CSVRowError
+
+// Error implements the error interface.
+func (e CSVErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("productplan: %v invalid CSV rows: %v", len(e), strings.Join(messages, "; "))
+}
+
+// csvColumns is the role of each column of a CSV file.
+type csvColumns struct {
+	headers []string
+
+	// attributes by column index, for the mapped columns
+	attributes map[int]string
+
+	// fields by column index, for the other columns
+	fields map[int]string
+}
+
+// newCSVColumns matches the header row against the mapping.
+func newCSVColumns(headers []string, mapping *CSVMapping) (*csvColumns, error) {
+	if mapping.Name != "" && containsFold(mapping.Ignore, mapping.Name) {
+		return nil, fmt.Errorf("name column %q is ignored", mapping.Name)
+	}
+
+	columns := &csvColumns{headers: headers, attributes: make(map[int]string), fields: make(map[int]string)}
+
+	attributes := map[string]string{
+		"name":            mapping.Name,
+		"description":     mapping.Description,
+		"strategic_value": mapping.StrategicValue,
+		"notes":           mapping.Notes,
+		"percent_done":    mapping.PercentDone,
+		"effort":          mapping.Effort,
+		"tags":            mapping.Tags,
+	}
+
+	seen := make(map[string]bool, len(headers))
+	for i, header := range headers {
+		header = strings.TrimSpace(strings.TrimPrefix(header, "\ufeff"))
+		headers[i] = header
+
+		key := strings.ToLower(header)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate column %q", header)
+		}
+		seen[key] = true
+
+		if containsFold(mapping.Ignore, header) {
+			continue
+		}
+
+		mapped := false
+		for attribute, column := range attributes {
+			if column != "" && strings.EqualFold(column, header) {
+				columns.attributes[i] = attribute
+				mapped = true
+			}
+		}
+		if mapped {
+			continue
+		}
+
+		field := header
+		for column, name := range mapping.Fields {
+			if strings.EqualFold(column, header) {
+				field = name
+			}
+		}
+		columns.fields[i] = field
+	}
+
+	if mapping.Name == "" || !seen[strings.ToLower(mapping.Name)] {
+		return nil, fmt.Errorf("missing name column %q", mapping.Name)
+	}
+	return columns, nil
+}
+
+// containsFold reports whether values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// idea builds the idea of a row, or returns the errors of its invalid cells.
+func (c *csvColumns) idea(line int, record []string, tagSeparator string) (Ideas, CSVErrors) {
+	idea := Ideas{}
+	var errs CSVErrors
+
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+
+		if field, ok := c.fields[i]; ok {
+			if value != "" {
+				if idea.Fields == nil {
+					idea.Fields = make(map[string]string)
+				}
+				idea.Fields[field] = value
+			}
+			continue
+		}
+
+		var err error
+		switch c.attributes[i] {
+		case "name":
+			idea.Name = value
+		case "description":
+			idea.Description = value
+		case "strategic_value":
+			idea.StrategicValue = value
+		case "notes":
+			idea.Notes = value
+		case "percent_done":
+			idea.PercentDone, err = parseCSVInt(value, 0, 100)
+		case "effort":
+			idea.Effort, err = parseCSVInt(value, 0, -1)
+		case "tags":
+			for _, tag := range strings.Split(value, tagSeparator) {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					idea.Tags = append(idea.Tags, tag)
+				}
+			}
+		}
+		if err != nil {
+			errs = append(errs, &CSVRowError{Line: line, Column: c.headers[i], Err: err})
+		}
+	}
+
+	if idea.Name == "" {
+		errs = append(errs, &CSVRowError{Line: line, Err: errors.New("name is required")})
+	}
+	return idea, errs
+}
+
+// parseCSVInt parses an integer cell, empty meaning 0. A negative max means no maximum.
+func parseCSVInt(value string, min, max int) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+	if n < min || (max >= 0 && n > max) {
+		return 0, fmt.Errorf("%v out of range", n)
+	}
+	return n, nil
+}
+
+// ReadIdeasCSV reads ideas from a CSV file whose first row holds the column headers.
+// Every row is validated: when some rows are invalid, the ideas of the valid rows are returned
+// along with CSVErrors listing the errors of the invalid ones.
+func ReadIdeasCSV(r io.Reader, options *CSVOptions) ([]Ideas, error) {
+	mapping := DefaultCSVMapping()
+	reader := csv.NewReader(r)
+	if options != nil {
+		if options.Mapping != nil {
+			mapping = options.Mapping
+		}
+		if options.Comma != 0 {
+			reader.Comma = options.Comma
+		}
+	}
+	tagSeparator := mapping.TagSeparator
+	if tagSeparator == "" {
+		tagSeparator = ","
+	}
+
+	headers, err := reader.Read()
+	if err == io.EOF {
+		return nil, CSVErrors{{Line: 1, Err: errors.New("missing header row")}}
+	}
+	if err != nil {
+		return nil, CSVErrors{{Line: 1, Err: err}}
+	}
+
+	columns, err := newCSVColumns(headers, mapping)
+	if err != nil {
+		return nil, CSVErrors{{Line: 1, Err: err}}
+	}
+
+	var ideas []Ideas
+	var errs CSVErrors
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			errs = append(errs, &CSVRowError{Line: parseErr.StartLine, Err: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		idea, rowErrs := columns.idea(line, record, tagSeparator)
+		if len(rowErrs) > 0 {
+			errs = append(errs, rowErrs...)
+			continue
+		}
+		ideas = append(ideas, idea)
+	}
+
+	if len(errs) > 0 {
+		return ideas, errs
+	}
+	return ideas, nil
+}
+
+// PreviewCSVImport returns the import that ImportCSV would send for a CSV file, without sending it.
+// It fails with CSVErrors if any row is invalid.
+func PreviewCSVImport(roadmapID int, r io.Reader, options *CSVOptions) (*IdeasImportAttributes, error) {
+	ideas, err := ReadIdeasCSV(r, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IdeasImportAttributes{
+		IdeaImportRoadmap: IdeaImportRoadmap{ID: roadmapID},
+		Ideas:             ideas,
+	}, nil
+}
+
+// ImportCSV imports the ideas of a CSV file onto a roadmap, see ReadIdeasCSV.
+// Nothing is imported if any row is invalid.
+func (s *IdeasService) ImportCSV(roadmapID int, r io.Reader, options *CSVOptions) (*IdeasImportResponse, error) {
+	return s.ImportCSVWithContext(context.Background(), roadmapID, r, options)
+}
+
+// ImportCSVWithContext imports the ideas of a CSV file onto a roadmap using the given context, see ImportCSV.
+func (s *IdeasService) ImportCSVWithContext(ctx context.Context, roadmapID int, r io.Reader, options *CSVOptions) (*IdeasImportResponse, error) {
+	ideasImportAttributes, err := PreviewCSVImport(roadmapID, r, options)
+	if err != nil {
+		return nil, err
+	}
+	return s.ImportWithContext(ctx, *ideasImportAttributes)
+}
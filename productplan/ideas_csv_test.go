@@ -0,0 +1,150 @@
+package productplan
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testIdeasCSV = "\ufeffName,Description,Strategic_Value,Percent_Done,Effort,Tags,pp_lanes,Owner\n" +
+	"Export CSV,\"Export the roadmap, as CSV\",high,10%,3,\"export, csv\",Lane 1,ana\n" +
+	"Dark mode,,low,,,,,\n"
+
+func TestReadIdeasCSV(t *testing.T) {
+	mapping := DefaultCSVMapping()
+	mapping.Ignore = []string{"owner"}
+
+	ideas, err := ReadIdeasCSV(strings.NewReader(testIdeasCSV), &CSVOptions{Mapping: mapping})
+	if err != nil {
+		t.Fatalf("ReadIdeasCSV() returned error: %v", err)
+	}
+
+	want := []Ideas{
+		{
+			Name:           "Export CSV",
+			Description:    "Export the roadmap, as CSV",
+			StrategicValue: "high",
+			PercentDone:    10,
+			Effort:         3,
+			Tags:           []string{"export", "csv"},
+			Fields:         map[string]string{"pp_lanes": "Lane 1"},
+		},
+		{Name: "Dark mode", StrategicValue: "low"},
+	}
+	if !reflect.DeepEqual(ideas, want) {
+		t.Errorf("ReadIdeasCSV() returned GOT: %+v, WANT %+v", ideas, want)
+	}
+}
+
+func TestReadIdeasCSV_Mapping(t *testing.T) {
+	input := "Title\tLane\tGoal\tSize\n" +
+		"Single sign-on\tLane 2\tGoal 4\t5\n"
+
+	mapping := &CSVMapping{
+		Name:   "Title",
+		Effort: "Size",
+		Fields: map[string]string{"Lane": "pp_lanes", "Goal": "pp_legend"},
+	}
+
+	ideas, err := ReadIdeasCSV(strings.NewReader(input), &CSVOptions{Mapping: mapping, Comma: '\t'})
+	if err != nil {
+		t.Fatalf("ReadIdeasCSV() returned error: %v", err)
+	}
+
+	want := []Ideas{{Name: "Single sign-on", Effort: 5, Fields: map[string]string{"pp_lanes": "Lane 2", "pp_legend": "Goal 4"}}}
+	if !reflect.DeepEqual(ideas, want) {
+		t.Errorf("ReadIdeasCSV() returned GOT: %+v, WANT %+v", ideas, want)
+	}
+}
+
+func TestReadIdeasCSV_Invalid(t *testing.T) {
+	input := "name,percent_done,effort\n" +
+		"Valid,50,1\n" +
+		",20,1\n" +
+		"Too done,120,x\n" +
+		"Short row\n" +
+		"\"Multi\nline\",0,1\n" +
+		"Last,0,1\n"
+
+	ideas, err := ReadIdeasCSV(strings.NewReader(input), nil)
+
+	var errs CSVErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("ReadIdeasCSV() error = %v, want CSVErrors", err)
+	}
+
+	var got []string
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		"line 3: name is required",
+		"line 4: percent_done: 120 out of range",
+		`line 4: effort: invalid number "x"`,
+		"line 5: wrong number of fields",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadIdeasCSV() errors GOT: %q, WANT %q", got, want)
+	}
+
+	// the valid rows are still returned
+	var names []string
+	for _, idea := range ideas {
+		names = append(names, idea.Name)
+	}
+	if want := []string{"Valid", "Multi\nline", "Last"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ReadIdeasCSV() ideas GOT: %q, WANT %q", names, want)
+	}
+}
+
+func TestReadIdeasCSV_MissingName(t *testing.T) {
+	_, err := ReadIdeasCSV(strings.NewReader("title,effort\nIdea,1\n"), nil)
+	if err == nil || !strings.Contains(err.Error(), `line 1: missing name column "name"`) {
+		t.Errorf("ReadIdeasCSV() error = %v, want the missing name column", err)
+	}
+}
+
+func TestReadIdeasCSV_IgnoredName(t *testing.T) {
+	mapping := DefaultCSVMapping()
+	mapping.Ignore = []string{"Name"}
+
+	_, err := ReadIdeasCSV(strings.NewReader("name,effort\nIdea,1\n"), &CSVOptions{Mapping: mapping})
+	if err == nil || !strings.Contains(err.Error(), `line 1: name column "name" is ignored`) {
+		t.Errorf("ReadIdeasCSV() error = %v, want the ignored name column", err)
+	}
+}
+
+func TestIdeasService_ImportCSV(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	preview, err := PreviewCSVImport(5912, strings.NewReader(testIdeasCSV), nil)
+	if err != nil {
+		t.Fatalf("PreviewCSVImport() returned error: %v", err)
+	}
+
+	mux.HandleFunc("/api/ideas/actions/import", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/ideas_import/idea_import_success.http")
+
+		testMethod(t, r, "POST")
+		testHeaders(t, r)
+
+		// the preview is exactly what is sent
+		got := IdeasImportAttributes{}
+		json.NewDecoder(r.Body).Decode(&got)
+		if !reflect.DeepEqual(&got, preview) {
+			t.Errorf("Request body GOT: %+v, WANT %+v", got, preview)
+		}
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	if _, err := client.Ideas.ImportCSV(5912, strings.NewReader(testIdeasCSV), nil); err != nil {
+		t.Fatalf("Ideas.ImportCSV() returned error: %v", err)
+	}
+}
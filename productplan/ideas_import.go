@@ -1,6 +1,7 @@
 package productplan
 
 import (
+	"context"
 	"io"
 )
 
@@ -22,16 +23,21 @@ type IdeasImportResponse struct {
 
 // Import handles ideas imports
 func (s *IdeasService) Import(ideasImportAttributes IdeasImportAttributes) (*IdeasImportResponse, error) {
+	return s.ImportWithContext(context.Background(), ideasImportAttributes)
+}
+
+// ImportWithContext handles ideas imports using the given context
+func (s *IdeasService) ImportWithContext(ctx context.Context, ideasImportAttributes IdeasImportAttributes) (*IdeasImportResponse, error) {
 	path := "/api/ideas/actions/import"
 	ideasImportResponse := &IdeasImportResponse{}
 
-	resp, err := s.client.post(path, ideasImportAttributes, ideasImportResponse)
+	resp, err := s.client.post(ctx, path, ideasImportAttributes, ideasImportResponse)
 
 	// import does not return any response data, so expect the response decode to be EOF
 	if err != nil && err != io.EOF {
 		return nil, err
 	}
 
-	ideasImportResponse.HTTPResponse = resp
+	ideasImportResponse.Response = newResponse(resp)
 	return ideasImportResponse, nil
 }
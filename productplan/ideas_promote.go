@@ -0,0 +1,119 @@
+package productplan
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// PromoteIdea represents the placement of an idea promoted from the parking lot onto the timeline.
+type PromoteIdea struct {
+	// RoadmapID of the new bar. Defaults to the roadmap of the idea.
+	RoadmapID int
+
+	// Lane and Legend of the new bar. Default to the ones of the idea.
+	Lane   string
+	Legend string
+
+	// StartDate and EndDate of the new bar, as YYYY-MM-DD.
+	StartDate string
+	EndDate   string
+
+	// RemoveIdea deletes the idea once the bar is created.
+	RemoveIdea bool
+}
+
+// Promote creates a bar from an idea, carrying its description, strategic value, notes, effort,
+// tags, fields and external links across.
+func (s *IdeasService) Promote(idea Ideas, placement PromoteIdea) (*BarsResponse, error) {
+	return s.PromoteWithContext(context.Background(), idea, placement)
+}
+
+// PromoteByID creates a bar from the idea with the given ID, see Promote.
+func (s *IdeasService) PromoteByID(id int, placement PromoteIdea) (*BarsResponse, error) {
+	return s.PromoteByIDWithContext(context.Background(), id, placement)
+}
+
+// PromoteByIDWithContext creates a bar from the idea with the given ID using the given context
+func (s *IdeasService) PromoteByIDWithContext(ctx context.Context, id int, placement PromoteIdea) (*BarsResponse, error) {
+	ideasResponse, err := s.ShowWithContext(ctx, strconv.Itoa(id))
+	if err != nil {
+		return nil, err
+	}
+	return s.PromoteWithContext(ctx, ideasResponse.Ideas, placement)
+}
+
+// PromoteWithContext creates a bar from an idea using the given context.
+//
+// Once the bar is created, a failure to copy the external links or to remove the idea
+// is returned along with the new bar, so that the operation is not repeated.
+func (s *IdeasService) PromoteWithContext(ctx context.Context, idea Ideas, placement PromoteIdea) (*BarsResponse, error) {
+	roadmapID := placement.RoadmapID
+	if roadmapID == 0 {
+		roadmapID = ideaRoadmapID(idea)
+	}
+	if roadmapID == 0 {
+		return nil, fmt.Errorf("productplan: idea %v has no roadmap, set PromoteIdea.RoadmapID", idea.ID)
+	}
+
+	fields := make(map[string]string, len(idea.Fields)+2)
+	for name, value := range idea.Fields {
+		fields[name] = value
+	}
+	if placement.Lane != "" {
+		fields[fieldLane] = placement.Lane
+	}
+	if placement.Legend != "" {
+		fields[fieldLegend] = placement.Legend
+	}
+
+	barAttributes := CreateBar{
+		Name:           idea.Name,
+		StartDate:      placement.StartDate,
+		EndDate:        placement.EndDate,
+		Description:    idea.Description,
+		StrategicValue: idea.StrategicValue,
+		Notes:          idea.Notes,
+		PercentDone:    idea.PercentDone,
+		Effort:         idea.Effort,
+		Tags:           idea.Tags,
+		Fields:         fields,
+	}
+
+	bars := s.client.Bars
+	barsResponse, err := bars.CreateBarWithContext(ctx, roadmapID, barAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	if idea.ID != 0 {
+		links := newIterator(ctx, func(ctx context.Context, cursor *Cursor) (*ListResponse[ExternalLink], error) {
+			return s.ListExternalLinksWithContext(ctx, idea, withCursor(nil, cursor))
+		})
+		for links.Next() {
+			link := links.Value()
+			if _, err := bars.AddExternalLinkWithContext(ctx, barsResponse.Bar, ExternalLink{URL: link.URL, Title: link.Title}); err != nil {
+				return barsResponse, fmt.Errorf("productplan: bar %v created, copying the external links of idea %v: %w", barsResponse.ID, idea.ID, err)
+			}
+		}
+		if err := links.Err(); err != nil {
+			return barsResponse, fmt.Errorf("productplan: bar %v created, listing the external links of idea %v: %w", barsResponse.ID, idea.ID, err)
+		}
+	}
+
+	if placement.RemoveIdea && idea.ID != 0 {
+		if _, err := s.DeleteIdeaWithContext(ctx, idea.ID); err != nil {
+			return barsResponse, fmt.Errorf("productplan: bar %v created, removing idea %v: %w", barsResponse.ID, idea.ID, err)
+		}
+	}
+
+	return barsResponse, nil
+}
+
+// ideaRoadmapID returns the ID of the roadmap of an idea, from its link, or 0.
+func ideaRoadmapID(idea Ideas) int {
+	if idea.IdeaLinks == nil {
+		return 0
+	}
+	return hrefID(idea.IdeaLinks.Roadmap["href"])
+}
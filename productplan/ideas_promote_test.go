@@ -0,0 +1,147 @@
+package productplan
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func setupPromote(t *testing.T) *[]string {
+	var calls []string
+
+	mux.HandleFunc("/api/ideas/110689", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		fixture := "/ideas/success.http"
+		if r.Method == "DELETE" {
+			fixture = "/ideas/idea_delete.http"
+		}
+		httpResponse := httpResponseFixture(t, fixture)
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+	mux.HandleFunc("/api/ideas/110689/external_links", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		httpResponse := httpResponseFixture(t, "/external_links/list_external_links_success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+	mux.HandleFunc("/api/roadmaps/5912/bars", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		testMethod(t, r, "POST")
+
+		var got CreateBar
+		json.NewDecoder(r.Body).Decode(&got)
+		want := CreateBar{
+			Name:           "ImplementationIdeaTest",
+			StartDate:      "2018-10-01",
+			EndDate:        "2018-12-21",
+			Description:    "Implement and test the feature.",
+			StrategicValue: "polarity",
+			Notes:          "autotoxication",
+			PercentDone:    78,
+			Effort:         2,
+			Tags:           []string{"electrotypy", "monoservice"},
+			Fields:         map[string]string{"pp_lanes": "Lane 3", "pp_legend": "Goal 1"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Request body GOT: %+v, WANT %+v", got, want)
+		}
+
+		httpResponse := httpResponseFixture(t, "/bars/create_bar_success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	return &calls
+}
+
+func TestIdeasService_PromoteByID(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	calls := setupPromote(t)
+
+	var links []string
+	mux.HandleFunc("/api/bars/205500/external_links", func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, r.Method+" "+r.URL.Path)
+
+		var link ExternalLink
+		json.NewDecoder(r.Body).Decode(&link)
+		links = append(links, link.URL)
+
+		httpResponse := httpResponseFixture(t, "/external_links/add_external_link_success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	placement := PromoteIdea{Lane: "Lane 3", StartDate: "2018-10-01", EndDate: "2018-12-21", RemoveIdea: true}
+
+	barsResponse, err := client.Ideas.PromoteByID(110689, placement)
+	if err != nil {
+		t.Fatalf("Ideas.PromoteByID() returned error: %v", err)
+	}
+	if barsResponse.ID != 205500 {
+		t.Errorf("Ideas.PromoteByID() returned bar %v, want %v", barsResponse.ID, 205500)
+	}
+
+	wantLinks := []string{"https://jira.example.com/browse/PLAN-42", "https://github.com/example/app/pull/7"}
+	if !reflect.DeepEqual(links, wantLinks) {
+		t.Errorf("external links copied %v, want %v", links, wantLinks)
+	}
+
+	wantCalls := []string{
+		"GET /api/ideas/110689",
+		"POST /api/roadmaps/5912/bars",
+		"GET /api/ideas/110689/external_links",
+		"POST /api/bars/205500/external_links",
+		"POST /api/bars/205500/external_links",
+		"DELETE /api/ideas/110689",
+	}
+	if !reflect.DeepEqual(*calls, wantCalls) {
+		t.Errorf("requests sent %v, want %v", *calls, wantCalls)
+	}
+}
+
+func TestIdeasService_Promote_LinkFailure(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	calls := setupPromote(t)
+
+	mux.HandleFunc("/api/bars/205500/external_links", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		io.WriteString(w, `{"message":"Validation failed","errors":{"url":["is invalid"]}}`)
+	})
+
+	idea := Ideas{ID: 110689, Name: "ImplementationIdeaTest", Description: "Implement and test the feature.",
+		StrategicValue: "polarity", Notes: "autotoxication", PercentDone: 78, Effort: 2,
+		Tags:      []string{"electrotypy", "monoservice"},
+		Fields:    map[string]string{"pp_lanes": "Lane 2", "pp_legend": "Goal 1"},
+		IdeaLinks: &IdeaLinks{Roadmap: map[string]string{"href": "/api/roadmaps/5912"}},
+	}
+
+	barsResponse, err := client.Ideas.Promote(idea, PromoteIdea{Lane: "Lane 3", StartDate: "2018-10-01", EndDate: "2018-12-21", RemoveIdea: true})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("Ideas.Promote() error = %v, want %v", err, ErrValidation)
+	}
+
+	// the bar exists, and the idea is kept
+	if barsResponse == nil || barsResponse.ID != 205500 {
+		t.Errorf("Ideas.Promote() returned %+v, want the created bar", barsResponse)
+	}
+	for _, call := range *calls {
+		if call == "DELETE /api/ideas/110689" {
+			t.Errorf("Ideas.Promote() removed the idea after a failure")
+		}
+	}
+}
+
+func TestIdeasService_Promote_NoRoadmap(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	if _, err := client.Ideas.Promote(Ideas{ID: 1, Name: "Orphan"}, PromoteIdea{}); err == nil {
+		t.Errorf("Ideas.Promote() without roadmap should fail")
+	}
+}
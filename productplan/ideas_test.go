@@ -1,6 +1,8 @@
 package productplan
 
 import (
+	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"reflect"
@@ -61,3 +63,132 @@ func TestIdeas_Show(t *testing.T) {
 	}
 
 }
+
+func TestIdeasService_ListIdeas(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/ideas", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/ideas/list_ideas_success.http")
+
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+		if got, want := r.URL.Query().Get("order"), "name:desc"; got != want {
+			t.Errorf("order = %q, want %q", got, want)
+		}
+
+		w.Header().Set("Link", httpResponse.Header.Get("Link"))
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	ideasResponse, err := client.Ideas.ListIdeas(&ListOptions{Filters: "tags=electrotypy", Order: "name:desc"})
+	if err != nil {
+		t.Fatalf("Ideas.ListIdeas() returned error: %v", err)
+	}
+
+	if len(ideasResponse.Items) != 1 || ideasResponse.Items[0].ID != 110689 {
+		t.Errorf("Ideas.ListIdeas() returned GOT: %+v", ideasResponse.Items)
+	}
+
+	want := &Cursor{By: "id", From: "110689", Items: 500}
+	if got := ideasResponse.Pagination.Next; !reflect.DeepEqual(got, want) {
+		t.Errorf("Ideas.ListIdeas() next cursor GOT: %+v, WANT %+v", got, want)
+	}
+}
+
+func TestRoadmapsService_GetIdeas(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/roadmaps/5912/ideas", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/roadmaps/get_ideas_success.http")
+
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	roadmap := Roadmap{ID: 5912, RoadmapLinks: RoadmapLinks{Ideas: map[string]string{"href": "/api/roadmaps/5912/ideas"}}}
+
+	// the last page, iteration stops after it
+	ideas, err := ListAll(client.Roadmaps.IterIdeas(context.Background(), roadmap, nil), 0)
+	if err != nil {
+		t.Fatalf("Roadmaps.IterIdeas() returned error: %v", err)
+	}
+
+	var names []string
+	for _, idea := range ideas {
+		names = append(names, idea.Name)
+	}
+	if want := []string{"ImplementationIdeaTest", "ExportIdeaTest"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Roadmaps.IterIdeas() returned GOT: %v, WANT %v", names, want)
+	}
+}
+
+func TestIdeasService_UpdateIdea(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/ideas/110689", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/ideas/idea_update.http")
+
+		testMethod(t, r, "PATCH")
+		testHeaders(t, r)
+
+		var got map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&got)
+		want := map[string]interface{}{
+			"strategic_value": "high",
+			"tags":            []interface{}{"reviewed"},
+			"fields":          map[string]interface{}{"pp_lanes": "Lane 1"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Request body GOT: %v, WANT %v", got, want)
+		}
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	ideaAttributes := UpdateIdea{
+		StrategicValue: "high",
+		Tags:           []string{"reviewed"},
+		Fields:         map[string]string{"pp_lanes": "Lane 1"},
+	}
+
+	ideasResponse, err := client.Ideas.UpdateIdea(110689, ideaAttributes)
+	if err != nil {
+		t.Fatalf("Ideas.UpdateIdea() returned error: %v", err)
+	}
+
+	if ideasResponse.HTTPResponse.StatusCode != 204 {
+		t.Errorf("ideasResponse.HTTPResponse.StatusCode GOT: %+v", ideasResponse.HTTPResponse.StatusCode)
+	}
+}
+
+func TestIdeasService_DeleteIdea(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/ideas/110689", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/ideas/idea_delete.http")
+
+		testMethod(t, r, "DELETE")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	ideasResponse, err := client.Ideas.DeleteIdea(110689)
+	if err != nil {
+		t.Fatalf("Ideas.DeleteIdea() returned error: %v", err)
+	}
+
+	if ideasResponse.HTTPResponse.StatusCode != 204 {
+		t.Errorf("ideasResponse.HTTPResponse.StatusCode GOT: %+v", ideasResponse.HTTPResponse.StatusCode)
+	}
+}
@@ -0,0 +1,111 @@
+package productplan
+
+import (
+	"context"
+)
+
+// pageFetcher fetches the page of items starting at cursor.
+// A nil cursor selects the first page.
+type pageFetcher[T any] func(ctx context.Context, cursor *Cursor) (*ListResponse[T], error)
+
+// withCursor returns a copy of options selecting the page at cursor.
+// A nil cursor keeps the cursor of options, so iterations can start from any page.
+func withCursor(options *ListOptions, cursor *Cursor) *ListOptions {
+	pageOptions := ListOptions{}
+	if options != nil {
+		pageOptions = *options
+	}
+	if cursor != nil {
+		pageOptions.Cursor = cursor
+	}
+	return &pageOptions
+}
+
+// Iterator iterates over the items of a paginated list,
+// fetching the pages lazily as the items are consumed.
+//
+//	it := client.Roadmaps.IterRoadmaps(ctx, nil)
+//	for it.Next() {
+//		roadmap := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch pageFetcher[T]
+
+	items   []T
+	current T
+	cursor  *Cursor
+	last    bool
+	err     error
+}
+
+func newIterator[T any](ctx context.Context, fetch pageFetcher[T]) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch}
+}
+
+// Next advances the iterator to the next item, fetching the next page when needed.
+// It returns false when there are no more items, when a request failed,
+// or when the context of the iterator is done. Check Err to tell them apart.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	for len(it.items) == 0 {
+		if it.last {
+			return false
+		}
+
+		page, err := it.fetch(it.ctx, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = page.Items
+		pagination := page.Pagination
+
+		// stop on the last page, or if the API hands out the same cursor again
+		if pagination == nil || pagination.Next == nil ||
+			(it.cursor != nil && pagination.Next.String() == it.cursor.String()) {
+			it.last = true
+		} else {
+			it.cursor = pagination.Next
+		}
+	}
+
+	it.current = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// ListAll collects the remaining items of the iterator.
+// When limit is positive, at most limit items are returned and no further pages are fetched.
+func ListAll[T any](it *Iterator[T], limit int) ([]T, error) {
+	var all []T
+	for (limit <= 0 || len(all) < limit) && it.Next() {
+		all = append(all, it.Value())
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
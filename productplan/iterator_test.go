@@ -0,0 +1,117 @@
+package productplan
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// setupPagedBars serves 5 bars on /api/bars, 2 per page.
+func setupPagedBars(t *testing.T) *int {
+	requests := 0
+	mux.HandleFunc("/api/bars", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+
+		from := 0
+		if pagination := r.URL.Query().Get("pagination"); pagination != "" {
+			cursor, err := ParseCursor(pagination)
+			if err != nil {
+				t.Fatalf("ParseCursor() returned error: %v", err)
+			}
+			fmt.Sscan(cursor.From, &from)
+		}
+
+		var bars []string
+		for id := from + 1; id <= from+2 && id <= 5; id++ {
+			bars = append(bars, fmt.Sprintf(`{"id":%d,"name":"Bar%d"}`, id, id))
+		}
+
+		link := `</api/bars?pagination=by%3Did%2Citems%3D2>; rel="first"`
+		if from+2 < 5 {
+			link = fmt.Sprintf(`</api/bars?pagination=by%%3Did%%2Cfrom%%3D%d%%2Citems%%3D2>; rel="next", `, from+2) + link
+		}
+		w.Header().Set("Link", link)
+
+		fmt.Fprintf(w, "[%s]", strings.Join(bars, ","))
+	})
+	return &requests
+}
+
+func barIDs(bars []Bar) []int {
+	var ids []int
+	for _, bar := range bars {
+		ids = append(ids, bar.ID)
+	}
+	return ids
+}
+
+func TestBarsService_IterBars(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	requests := setupPagedBars(t)
+
+	it := client.Bars.IterBars(context.Background(), nil)
+
+	var got []int
+	for it.Next() {
+		got = append(got, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Bars.IterBars() returned error: %v", err)
+	}
+
+	want := []int{1, 2, 3, 4, 5}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Bars.IterBars() returned %v, want %v", got, want)
+	}
+	if *requests != 3 {
+		t.Errorf("Bars.IterBars() sent %v requests, want %v", *requests, 3)
+	}
+}
+
+func TestListAll_Limit(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	requests := setupPagedBars(t)
+
+	bars, err := ListAll(client.Bars.IterBars(context.Background(), nil), 3)
+	if err != nil {
+		t.Fatalf("ListAll() returned error: %v", err)
+	}
+
+	if got, want := barIDs(bars), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListAll() returned %v, want %v", got, want)
+	}
+	if *requests != 2 {
+		t.Errorf("ListAll() sent %v requests, want %v", *requests, 2)
+	}
+}
+
+func TestIterator_ContextCanceled(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	requests := setupPagedBars(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := client.Bars.IterBars(ctx, nil)
+
+	if !it.Next() {
+		t.Fatalf("Iterator.Next() returned false, err %v", it.Err())
+	}
+	cancel()
+
+	if it.Next() {
+		t.Errorf("Iterator.Next() returned true after cancel")
+	}
+	if err := it.Err(); err != context.Canceled {
+		t.Errorf("Iterator.Err() = %v, want %v", err, context.Canceled)
+	}
+	if *requests != 1 {
+		t.Errorf("Iterator sent %v requests, want %v", *requests, 1)
+	}
+}
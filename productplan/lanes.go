@@ -0,0 +1,117 @@
+package productplan
+
+import (
+	"context"
+	"errors"
+)
+
+// Fields of a bar or an idea holding the name of its lane and of its legend.
+const (
+	fieldLane   = "pp_lanes"
+	fieldLegend = "pp_legend"
+)
+
+// ErrNoLane is returned when moving a bar to a lane that is not on its roadmap.
+var ErrNoLane = errors.New("productplan: lane not found on the roadmap")
+
+// LanesService handles communication with the lane
+// methods of the Productplan API.
+type LanesService struct {
+	client *Client
+}
+
+// Lane represents a lane of a roadmap, grouping its bars horizontally.
+type Lane struct {
+	Href     string `json:"href,omitempty"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Position int    `json:"position,omitempty"`
+}
+
+func (l Lane) groupID() int      { return l.ID }
+func (l Lane) groupName() string { return l.Name }
+
+// laneKind lists the lanes of a roadmap, held by the pp_lanes field of the bars and ideas.
+var laneKind = groupKind[Lane]{
+	field:       fieldLane,
+	segment:     "lanes",
+	link:        func(links RoadmapLinks) map[string]string { return links.Lanes },
+	errNotFound: ErrNoLane,
+}
+
+// ListLanes get the lanes of a roadmap
+func (s *LanesService) ListLanes(roadmap Roadmap) (*ListResponse[Lane], error) {
+	return s.ListLanesWithContext(context.Background(), roadmap, nil)
+}
+
+// ListLanesWithContext get a page of the lanes of a roadmap using the given context,
+// following the lanes link of the roadmap when available.
+// Use options.Cursor to select the page to return.
+func (s *LanesService) ListLanesWithContext(ctx context.Context, roadmap Roadmap, options *ListOptions) (*ListResponse[Lane], error) {
+	return laneKind.list(ctx, s.client, roadmap, options)
+}
+
+// IterLanes iterates over all the lanes of a roadmap, fetching the pages as needed.
+func (s *LanesService) IterLanes(ctx context.Context, roadmap Roadmap, options *ListOptions) *Iterator[Lane] {
+	return laneKind.iter(ctx, s.client, roadmap, options)
+}
+
+// FindLane returns the lane with the given name, or false.
+func FindLane(lanes []Lane, name string) (Lane, bool) {
+	return findGroupByName(lanes, name)
+}
+
+// FindLaneByID returns the lane with the given ID, or false.
+func FindLaneByID(lanes []Lane, id int) (Lane, bool) {
+	return findGroupByID(lanes, id)
+}
+
+// LaneName returns the name of the lane of the bar, empty if it has none.
+func (b Bar) LaneName() string {
+	return b.Fields[fieldLane]
+}
+
+// Lane resolves the lane of the bar among the lanes of its roadmap, or returns false.
+func (b Bar) Lane(lanes []Lane) (Lane, bool) {
+	return laneKind.resolve(b.Fields, lanes)
+}
+
+// LaneName returns the name of the lane of the idea, empty if it has none.
+func (i Ideas) LaneName() string {
+	return i.Fields[fieldLane]
+}
+
+// Lane resolves the lane of the idea among the lanes of its roadmap, or returns false.
+func (i Ideas) Lane(lanes []Lane) (Lane, bool) {
+	return laneKind.resolve(i.Fields, lanes)
+}
+
+// MoveToLane moves a bar to a lane, keeping its other fields.
+func (s *BarsService) MoveToLane(bar Bar, lane Lane) (*BarsResponse, error) {
+	return s.MoveToLaneWithContext(context.Background(), bar, lane)
+}
+
+// MoveToLaneWithContext moves a bar to a lane using the given context
+func (s *BarsService) MoveToLaneWithContext(ctx context.Context, bar Bar, lane Lane) (*BarsResponse, error) {
+	return laneKind.move(ctx, s, bar, lane)
+}
+
+// MoveToLaneByName moves a bar to the lane with the given name on its roadmap, or returns ErrNoLane.
+func (s *BarsService) MoveToLaneByName(bar Bar, name string) (*BarsResponse, error) {
+	return s.MoveToLaneByNameWithContext(context.Background(), bar, name)
+}
+
+// MoveToLaneByNameWithContext moves a bar to the lane with the given name using the given context
+func (s *BarsService) MoveToLaneByNameWithContext(ctx context.Context, bar Bar, name string) (*BarsResponse, error) {
+	return laneKind.moveByName(ctx, s, bar, name)
+}
+
+// MoveToLaneByID moves a bar to the lane with the given ID on its roadmap, or returns ErrNoLane.
+func (s *BarsService) MoveToLaneByID(bar Bar, id int) (*BarsResponse, error) {
+	return s.MoveToLaneByIDWithContext(context.Background(), bar, id)
+}
+
+// MoveToLaneByIDWithContext moves a bar to the lane with the given ID using the given context
+func (s *BarsService) MoveToLaneByIDWithContext(ctx context.Context, bar Bar, id int) (*BarsResponse, error) {
+	return laneKind.moveByID(ctx, s, bar, id)
+}
@@ -0,0 +1,89 @@
+package productplan
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func setupLanes(t *testing.T) {
+	mux.HandleFunc("/api/roadmaps/7302/lanes", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/roadmaps/get_lanes_success.http")
+
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+}
+
+func TestLanesService_ListLanes(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	setupLanes(t)
+
+	lanesResponse, err := client.Lanes.ListLanes(Roadmap{ID: 7302})
+	if err != nil {
+		t.Fatalf("Lanes.ListLanes() returned error: %v", err)
+	}
+
+	want := Lane{Href: "/api/roadmaps/7302/lanes/1102", ID: 1102, Name: "Lane 2", Position: 2}
+	if len(lanesResponse.Items) != 3 {
+		t.Fatalf("Lanes.ListLanes() returned %v lanes, want %v", len(lanesResponse.Items), 3)
+	}
+	if got := lanesResponse.Items[1]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Lanes.ListLanes() returned GOT: %+v, WANT %+v", got, want)
+	}
+}
+
+func TestBar_Lane(t *testing.T) {
+	lanes := []Lane{{ID: 1101, Name: "Lane 1"}, {ID: 1102, Name: "Lane 2"}}
+
+	bar := Bar{Fields: map[string]string{"pp_lanes": "Lane 2"}}
+	if lane, ok := bar.Lane(lanes); !ok || lane.ID != 1102 {
+		t.Errorf("Bar.Lane() = %+v, %v, want lane 1102", lane, ok)
+	}
+
+	idea := Ideas{Fields: map[string]string{"pp_lanes": "Lane 3"}}
+	if lane, ok := idea.Lane(lanes); ok {
+		t.Errorf("Ideas.Lane() = %+v, want no lane", lane)
+	}
+	if (Ideas{}).LaneName() != "" {
+		t.Errorf("Ideas.LaneName() = %q, want empty", (Ideas{}).LaneName())
+	}
+}
+
+func TestBarsService_MoveToLane(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	setupLanes(t)
+	updated := setupUpdateBar(t)
+
+	bar := testBarOnRoadmap()
+	want := map[string]string{"pp_lanes": "Lane 3", "pp_legend": "Goal 1", "team": "Payments"}
+
+	if _, err := client.Bars.MoveToLaneByName(bar, "Lane 3"); err != nil {
+		t.Fatalf("Bars.MoveToLaneByName() returned error: %v", err)
+	}
+	if got := updated().Fields; !reflect.DeepEqual(got, want) {
+		t.Errorf("Bars.MoveToLaneByName() sent fields GOT: %v, WANT %v", got, want)
+	}
+	if bar.LaneName() != "Lane 1" {
+		t.Errorf("Bars.MoveToLaneByName() modified the fields of the bar")
+	}
+
+	if _, err := client.Bars.MoveToLaneByIDWithContext(context.Background(), bar, 1103); err != nil {
+		t.Fatalf("Bars.MoveToLaneByIDWithContext() returned error: %v", err)
+	}
+	if got := updated().Fields; !reflect.DeepEqual(got, want) {
+		t.Errorf("Bars.MoveToLaneByIDWithContext() sent fields GOT: %v, WANT %v", got, want)
+	}
+
+	if _, err := client.Bars.MoveToLaneByName(bar, "Lane 9"); !errors.Is(err, ErrNoLane) {
+		t.Errorf("Bars.MoveToLaneByName() error = %v, want %v", err, ErrNoLane)
+	}
+}
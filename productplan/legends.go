@@ -0,0 +1,111 @@
+package productplan
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoLegend is returned when moving a bar to a legend that is not on its roadmap.
+var ErrNoLegend = errors.New("productplan: legend not found on the roadmap")
+
+// LegendsService handles communication with the legend
+// methods of the Productplan API.
+type LegendsService struct {
+	client *Client
+}
+
+// Legend represents a legend of a roadmap, coloring its bars.
+type Legend struct {
+	Href  string `json:"href,omitempty"`
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+func (l Legend) groupID() int      { return l.ID }
+func (l Legend) groupName() string { return l.Name }
+
+// legendKind lists the legends of a roadmap, held by the pp_legend field of the bars and ideas.
+var legendKind = groupKind[Legend]{
+	field:       fieldLegend,
+	segment:     "legends",
+	link:        func(links RoadmapLinks) map[string]string { return links.Legends },
+	errNotFound: ErrNoLegend,
+}
+
+// ListLegends get the legends of a roadmap
+func (s *LegendsService) ListLegends(roadmap Roadmap) (*ListResponse[Legend], error) {
+	return s.ListLegendsWithContext(context.Background(), roadmap, nil)
+}
+
+// ListLegendsWithContext get a page of the legends of a roadmap using the given context,
+// following the legends link of the roadmap when available.
+// Use options.Cursor to select the page to return.
+func (s *LegendsService) ListLegendsWithContext(ctx context.Context, roadmap Roadmap, options *ListOptions) (*ListResponse[Legend], error) {
+	return legendKind.list(ctx, s.client, roadmap, options)
+}
+
+// IterLegends iterates over all the legends of a roadmap, fetching the pages as needed.
+func (s *LegendsService) IterLegends(ctx context.Context, roadmap Roadmap, options *ListOptions) *Iterator[Legend] {
+	return legendKind.iter(ctx, s.client, roadmap, options)
+}
+
+// FindLegend returns the legend with the given name, or false.
+func FindLegend(legends []Legend, name string) (Legend, bool) {
+	return findGroupByName(legends, name)
+}
+
+// FindLegendByID returns the legend with the given ID, or false.
+func FindLegendByID(legends []Legend, id int) (Legend, bool) {
+	return findGroupByID(legends, id)
+}
+
+// LegendName returns the name of the legend of the bar, empty if it has none.
+func (b Bar) LegendName() string {
+	return b.Fields[fieldLegend]
+}
+
+// Legend resolves the legend of the bar among the legends of its roadmap, or returns false.
+func (b Bar) Legend(legends []Legend) (Legend, bool) {
+	return legendKind.resolve(b.Fields, legends)
+}
+
+// LegendName returns the name of the legend of the idea, empty if it has none.
+func (i Ideas) LegendName() string {
+	return i.Fields[fieldLegend]
+}
+
+// Legend resolves the legend of the idea among the legends of its roadmap, or returns false.
+func (i Ideas) Legend(legends []Legend) (Legend, bool) {
+	return legendKind.resolve(i.Fields, legends)
+}
+
+// MoveToLegend moves a bar to a legend, keeping its other fields.
+func (s *BarsService) MoveToLegend(bar Bar, legend Legend) (*BarsResponse, error) {
+	return s.MoveToLegendWithContext(context.Background(), bar, legend)
+}
+
+// MoveToLegendWithContext moves a bar to a legend using the given context
+func (s *BarsService) MoveToLegendWithContext(ctx context.Context, bar Bar, legend Legend) (*BarsResponse, error) {
+	return legendKind.move(ctx, s, bar, legend)
+}
+
+// MoveToLegendByName moves a bar to the legend with the given name on its roadmap, or returns ErrNoLegend.
+func (s *BarsService) MoveToLegendByName(bar Bar, name string) (*BarsResponse, error) {
+	return s.MoveToLegendByNameWithContext(context.Background(), bar, name)
+}
+
+// MoveToLegendByNameWithContext moves a bar to the legend with the given name using the given context
+func (s *BarsService) MoveToLegendByNameWithContext(ctx context.Context, bar Bar, name string) (*BarsResponse, error) {
+	return legendKind.moveByName(ctx, s, bar, name)
+}
+
+// MoveToLegendByID moves a bar to the legend with the given ID on its roadmap, or returns ErrNoLegend.
+func (s *BarsService) MoveToLegendByID(bar Bar, id int) (*BarsResponse, error) {
+	return s.MoveToLegendByIDWithContext(context.Background(), bar, id)
+}
+
+// MoveToLegendByIDWithContext moves a bar to the legend with the given ID using the given context
+func (s *BarsService) MoveToLegendByIDWithContext(ctx context.Context, bar Bar, id int) (*BarsResponse, error) {
+	return legendKind.moveByID(ctx, s, bar, id)
+}
@@ -0,0 +1,85 @@
+package productplan
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func setupLegends(t *testing.T) {
+	mux.HandleFunc("/api/roadmaps/7302/legends", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/roadmaps/get_legends_success.http")
+
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+}
+
+func TestLegendsService_ListLegends(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	setupLegends(t)
+
+	roadmap := Roadmap{ID: 7302, RoadmapLinks: RoadmapLinks{Legends: map[string]string{"href": "/api/roadmaps/7302/legends"}}}
+
+	legendsResponse, err := client.Legends.ListLegends(roadmap)
+	if err != nil {
+		t.Fatalf("Legends.ListLegends() returned error: %v", err)
+	}
+
+	want := Legend{Href: "/api/roadmaps/7302/legends/1201", ID: 1201, Name: "Goal 1", Color: "#2b7bb9"}
+	if len(legendsResponse.Items) != 4 {
+		t.Fatalf("Legends.ListLegends() returned %v legends, want %v", len(legendsResponse.Items), 4)
+	}
+	if got := legendsResponse.Items[0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Legends.ListLegends() returned GOT: %+v, WANT %+v", got, want)
+	}
+}
+
+func TestBar_Legend(t *testing.T) {
+	legends := []Legend{{ID: 1201, Name: "Goal 1"}}
+
+	bar := Bar{Fields: map[string]string{"pp_legend": "Goal 3"}}
+	if legend, ok := bar.Legend(legends); ok {
+		t.Errorf("Bar.Legend() = %+v, want no legend", legend)
+	}
+
+	idea := Ideas{Fields: map[string]string{"pp_legend": "Goal 1"}}
+	if legend, ok := idea.Legend(legends); !ok || legend.ID != 1201 {
+		t.Errorf("Ideas.Legend() = %+v, %v, want legend 1201", legend, ok)
+	}
+}
+
+func TestBarsService_MoveToLegend(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	setupLegends(t)
+	updated := setupUpdateBar(t)
+
+	bar := testBarOnRoadmap()
+	want := map[string]string{"pp_lanes": "Lane 1", "pp_legend": "Goal 4", "team": "Payments"}
+
+	if _, err := client.Bars.MoveToLegendByID(bar, 1204); err != nil {
+		t.Fatalf("Bars.MoveToLegendByID() returned error: %v", err)
+	}
+	if got := updated().Fields; !reflect.DeepEqual(got, want) {
+		t.Errorf("Bars.MoveToLegendByID() sent fields GOT: %v, WANT %v", got, want)
+	}
+
+	if _, err := client.Bars.MoveToLegendWithContext(context.Background(), bar, Legend{ID: 1204, Name: "Goal 4"}); err != nil {
+		t.Fatalf("Bars.MoveToLegendWithContext() returned error: %v", err)
+	}
+	if got := updated().Fields; !reflect.DeepEqual(got, want) {
+		t.Errorf("Bars.MoveToLegendWithContext() sent fields GOT: %v, WANT %v", got, want)
+	}
+
+	if _, err := client.Bars.MoveToLegendByName(bar, "Goal 9"); !errors.Is(err, ErrNoLegend) {
+		t.Errorf("Bars.MoveToLegendByName() error = %v, want %v", err, ErrNoLegend)
+	}
+}
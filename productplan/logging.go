@@ -0,0 +1,217 @@
+package productplan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// LevelTrace is the log level of the request and response bodies,
+// below slog.LevelDebug used for the request and response events.
+const LevelTrace = slog.LevelDebug - 4
+
+// maxLogBodySize is the maximum number of body bytes logged at LevelTrace.
+const maxLogBodySize = 4096
+
+const redacted = "[REDACTED]"
+
+// defaultSensitiveFields are always redacted from the logged headers and JSON bodies.
+var defaultSensitiveFields = []string{
+	httpHeaderAuthorization,
+	"access_token",
+	"refresh_token",
+	"client_secret",
+	"password",
+}
+
+// logger returns the logger of the client.
+// Setting Debug without a Logger logs to stderr at LevelTrace.
+func (c *Client) logger() *slog.Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	if c.Debug {
+		return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: LevelTrace}))
+	}
+	return discardLogger
+}
+
+// isSensitive reports whether a header or JSON field must be redacted.
+func (c *Client) isSensitive(name string) bool {
+	for _, field := range defaultSensitiveFields {
+		if strings.EqualFold(name, field) {
+			return true
+		}
+	}
+	for _, field := range c.SensitiveFields {
+		if strings.EqualFold(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// logging is the middleware logging the requests sent and the responses received.
+func (c *Client) logging(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		logger := c.logger()
+		attempt := RequestAttempt(req)
+
+		c.logRequest(logger, req, attempt)
+		start := time.Now()
+
+		resp, err := next(req)
+		c.logResponse(logger, req, resp, err, attempt, time.Since(start))
+		return resp, err
+	}
+}
+
+// logRequest logs a request about to be sent.
+func (c *Client) logRequest(logger *slog.Logger, req *http.Request, attempt int) {
+	ctx := req.Context()
+	logger.LogAttrs(ctx, slog.LevelDebug, "productplan request",
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+	)
+
+	if !logger.Enabled(ctx, LevelTrace) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Any("headers", c.redactHeaders(req.Header)),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := ioutil.ReadAll(io.LimitReader(body, maxLogBodySize+1))
+			body.Close()
+			attrs = append(attrs, slog.String("body", c.redactBody(data)))
+		}
+	}
+	logger.LogAttrs(ctx, LevelTrace, "productplan request body", attrs...)
+}
+
+// logResponse logs the outcome of a request. At LevelTrace, the beginning of the body is logged too,
+// and resp.Body is replaced so that it can still be read entirely.
+func (c *Client) logResponse(logger *slog.Logger, req *http.Request, resp *http.Response, err error, attempt int, duration time.Duration) {
+	ctx := req.Context()
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", duration),
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		logger.LogAttrs(ctx, slog.LevelWarn, "productplan request failed", attrs...)
+		return
+	}
+
+	attrs = append(attrs,
+		slog.Int("status", resp.StatusCode),
+		slog.String("request_id", resp.Header.Get("X-Request-Id")),
+	)
+	logger.LogAttrs(ctx, slog.LevelDebug, "productplan response", attrs...)
+
+	if !logger.Enabled(ctx, LevelTrace) {
+		return
+	}
+
+	data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxLogBodySize+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+
+	logger.LogAttrs(ctx, LevelTrace, "productplan response body",
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("status", resp.StatusCode),
+		slog.Any("headers", c.redactHeaders(resp.Header)),
+		slog.String("body", c.redactBody(data)),
+	)
+}
+
+// logRetry logs a request about to be retried.
+func (c *Client) logRetry(logger *slog.Logger, req *http.Request, attempt int, delay time.Duration) {
+	logger.LogAttrs(req.Context(), slog.LevelInfo, "productplan retry",
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Duration("delay", delay),
+	)
+}
+
+// redactHeaders returns the headers to log, with the sensitive values redacted.
+func (c *Client) redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if c.isSensitive(name) {
+			headers[name] = redacted
+		} else {
+			headers[name] = strings.Join(values, ", ")
+		}
+	}
+	return headers
+}
+
+// redactBody returns the body to log, truncated, with the sensitive JSON fields redacted.
+func (c *Client) redactBody(data []byte) string {
+	var value interface{}
+	if len(data) <= maxLogBodySize && json.Unmarshal(data, &value) == nil {
+		if redactedJSON, err := json.Marshal(c.redactValue(value)); err == nil {
+			return string(redactedJSON)
+		}
+	}
+
+	// truncated or not JSON, the fields cannot be redacted reliably
+	body := truncate(string(data), maxLogBodySize)
+	lower := strings.ToLower(body)
+	for _, fields := range [][]string{defaultSensitiveFields, c.SensitiveFields} {
+		for _, field := range fields {
+			if strings.Contains(lower, strings.ToLower(field)) {
+				return redacted
+			}
+		}
+	}
+	return body
+}
+
+func (c *Client) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if c.isSensitive(key) {
+				v[key] = redacted
+			} else {
+				v[key] = c.redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = c.redactValue(item)
+		}
+	}
+	return value
+}
+
+// discardLogger is a logger with every level disabled, used when logging is not configured.
+var discardLogger = slog.New(discardHandler{})
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
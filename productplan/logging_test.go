@@ -0,0 +1,105 @@
+package productplan
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_Logger(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	var buf bytes.Buffer
+	client.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "7d10b146-6872-4ad1-a065-86d7e84ad390")
+		httpResponse := httpResponseFixture(t, "/status/success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	if _, err := client.Status.GetStatus(); err != nil {
+		t.Fatalf("Status.GetStatus() returned error: %v", err)
+	}
+
+	var events []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var event map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("log line %q is not JSON: %v", line, err)
+		}
+		events = append(events, event)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Client logged %v events, want %v: %v", len(events), 2, buf.String())
+	}
+
+	response := events[1]
+	if response["msg"] != "productplan response" || response["path"] != "/api/status" ||
+		response["status"] != float64(200) || response["request_id"] != "7d10b146-6872-4ad1-a065-86d7e84ad390" {
+		t.Errorf("Client logged response event %v", response)
+	}
+	if _, ok := response["duration"]; !ok {
+		t.Errorf("Client logged response event without duration %v", response)
+	}
+}
+
+func TestClient_Logger_TraceRedacted(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	var buf bytes.Buffer
+	client.Logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: LevelTrace}))
+	client.SensitiveFields = []string{"notes"}
+
+	mux.HandleFunc("/api/bars/205400", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/bars/bar_update.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	_, err := client.Bars.UpdateBar(205400, UpdateBar{Name: "Renamed bar", Notes: "confidential"})
+	if err != nil {
+		t.Fatalf("Bars.UpdateBar() returned error: %v", err)
+	}
+
+	logs := buf.String()
+	for _, secret := range []string{"productplan-token", "confidential"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("Client logged %q: %v", secret, logs)
+		}
+	}
+	for _, want := range []string{"Renamed bar", "productplan request body", "productplan response body", redacted} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("Client did not log %q: %v", want, logs)
+		}
+	}
+}
+
+func TestClient_Logger_TraceKeepsBody(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	client.Logger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: LevelTrace}))
+
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/status/success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	statusResponse, err := client.Status.GetStatus()
+	if err != nil {
+		t.Fatalf("Status.GetStatus() returned error: %v", err)
+	}
+	if statusResponse.Status.Application != "up" {
+		t.Errorf("statusResponse.Status.Application returned %+v", statusResponse.Status.Application)
+	}
+}
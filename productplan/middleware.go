@@ -0,0 +1,77 @@
+package productplan
+
+import (
+	"context"
+	"net/http"
+)
+
+// Handler sends an API request and returns its response.
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a Handler with additional behaviour.
+//
+// A middleware can modify the request before passing it to next,
+// inspect or replace the response returned by next,
+// or short-circuit the chain by returning a response without calling next.
+// Such a response must have a non-nil Body. Its Request defaults to the request
+// the middleware received.
+type Middleware func(next Handler) Handler
+
+// Use appends middlewares to the client.
+//
+// Middlewares run in the order they were added, the first one being the outermost.
+// They run once per attempt: retries, as configured by the RetryPolicy, go through the whole chain again,
+// and RequestAttempt tells which attempt is being sent. Rate limiting and logging happen after
+// the middlewares, so the logged requests include their modifications.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.Middlewares = append(c.Middlewares, middlewares...)
+}
+
+// WithMiddleware appends middlewares to the client, see Client.Use.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.Use(middlewares...)
+	}
+}
+
+type attemptKey struct{}
+
+// RequestAttempt returns the attempt number of a request going through the middlewares, starting at 1.
+func RequestAttempt(req *http.Request) int {
+	if attempt, ok := req.Context().Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
+
+// withAttempt returns a shallow copy of req carrying the attempt number.
+func withAttempt(req *http.Request, attempt int) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), attemptKey{}, attempt))
+}
+
+// handler builds the chain of handlers a request goes through:
+// retries, reauthentication, caching, then the client middlewares, rate limiting, logging
+// and finally the HTTP client.
+func (c *Client) handler() Handler {
+	h := c.logging(c.roundTrip)
+	h = c.rateLimit(h)
+	for i := len(c.Middlewares) - 1; i >= 0; i-- {
+		h = c.Middlewares[i](h)
+	}
+	h = withRequest(h)
+	h = c.cache(h)
+	h = c.reauthenticate(h)
+	return c.retry(h)
+}
+
+// withRequest sets the Request of the responses returned without one, eg. by a middleware
+// short-circuiting the chain, as ErrorResponse and the logs rely on it.
+func withRequest(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		resp, err := next(req)
+		if resp != nil && resp.Request == nil {
+			resp.Request = req
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,141 @@
+package productplan
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClient_Use_Order(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "X-Correlation-Id", "outer,inner")
+
+		httpResponse := httpResponseFixture(t, "/status/success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" request")
+				if id := req.Header.Get("X-Correlation-Id"); id != "" {
+					req.Header.Set("X-Correlation-Id", id+","+name)
+				} else {
+					req.Header.Set("X-Correlation-Id", name)
+				}
+
+				resp, err := next(req)
+				calls = append(calls, name+" response")
+				return resp, err
+			}
+		}
+	}
+	client.Use(trace("outer"), trace("inner"))
+
+	if _, err := client.Status.GetStatus(); err != nil {
+		t.Fatalf("Status.GetStatus() returned error: %v", err)
+	}
+
+	want := []string{"outer request", "inner request", "inner response", "outer response"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("middlewares called %v, want %v", calls, want)
+	}
+}
+
+func TestClient_Use_ShortCircuit(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request should not reach the server")
+	})
+
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"status":{"application":"up","database":"down"}}`)),
+				Request:    req,
+			}, nil
+		}
+	})
+
+	statusResponse, err := client.Status.GetStatus()
+	if err != nil {
+		t.Fatalf("Status.GetStatus() returned error: %v", err)
+	}
+
+	want := Status{Application: "up", Database: "down"}
+	if statusResponse.Status != want {
+		t.Errorf("statusResponse.Status returned %+v, want %+v", statusResponse.Status, want)
+	}
+}
+
+func TestClient_Use_ShortCircuitError(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"message":"injected"}`)),
+			}, nil
+		}
+	})
+
+	_, err := client.Status.GetStatus()
+	if err == nil {
+		t.Fatalf("Status.GetStatus() returned no error")
+	}
+
+	if want := "GET " + server.URL + "/api/status: 503 injected"; err.Error() != want {
+		t.Errorf("Status.GetStatus() error = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestClient_Use_FaultInjectionRetried(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	client.RetryPolicy = testRetryPolicy()
+
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/status/success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	var attempts []int
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			attempts = append(attempts, RequestAttempt(req))
+			if RequestAttempt(req) == 1 {
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Header:     http.Header{},
+					Body:       ioutil.NopCloser(strings.NewReader(`{"message":"injected"}`)),
+					Request:    req,
+				}, nil
+			}
+			return next(req)
+		}
+	})
+
+	if _, err := client.Status.GetStatus(); err != nil {
+		t.Fatalf("Status.GetStatus() returned error: %v", err)
+	}
+
+	if want := []int{1, 2}; !reflect.DeepEqual(attempts, want) {
+		t.Errorf("middleware saw attempts %v, want %v", attempts, want)
+	}
+}
@@ -0,0 +1,246 @@
+package productplan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiry an access token is refreshed,
+// so that it does not expire while a request is in flight.
+const tokenExpiryDelta = 30 * time.Second
+
+// oauth2Token represents a token response of an OAuth2 token endpoint.
+type oauth2Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+
+	fetchedAt time.Time
+	expiry    time.Time
+}
+
+func (t *oauth2Token) valid(now time.Time) bool {
+	return t != nil && t.AccessToken != "" && (t.expiry.IsZero() || now.Add(tokenExpiryDelta).Before(t.expiry))
+}
+
+// OAuth2Error represents an error response of an OAuth2 token endpoint.
+type OAuth2Error struct {
+	StatusCode  int
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// Error implements the error interface.
+func (e *OAuth2Error) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("productplan: oauth2 token request failed: %v %v: %v", e.StatusCode, e.Code, e.Description)
+	}
+	return fmt.Sprintf("productplan: oauth2 token request failed: %v %v", e.StatusCode, e.Code)
+}
+
+// Is matches ErrUnauthorized, as the client credentials or the refresh token were rejected.
+func (e *OAuth2Error) Is(target error) bool {
+	return target == ErrUnauthorized
+}
+
+// OAuth2 token authentication, obtaining and refreshing access tokens from a token endpoint
+type oauth2Credentials struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	grantType    string
+	refreshToken string
+	scopes       []string
+
+	// httpClient used for the token requests
+	httpClient *http.Client
+
+	// lock is a semaphore rather than a mutex, so that waiting for it respects the context
+	lock  chan struct{}
+	token *oauth2Token
+}
+
+// NewRefreshTokenCredentials construct Credentials obtaining access tokens from the OAuth2 token endpoint
+// at tokenURL, using the refresh_token grant. A refresh token returned by the endpoint replaces the given one.
+func NewRefreshTokenCredentials(tokenURL, clientID, clientSecret, refreshToken string) RefreshableCredentials {
+	return newOauth2Credentials(tokenURL, clientID, clientSecret, "refresh_token", refreshToken, nil)
+}
+
+// NewClientCredentials construct Credentials obtaining access tokens from the OAuth2 token endpoint
+// at tokenURL, using the client_credentials grant.
+func NewClientCredentials(tokenURL, clientID, clientSecret string, scopes ...string) RefreshableCredentials {
+	return newOauth2Credentials(tokenURL, clientID, clientSecret, "client_credentials", "", scopes)
+}
+
+func newOauth2Credentials(tokenURL, clientID, clientSecret, grantType, refreshToken string, scopes []string) *oauth2Credentials {
+	return &oauth2Credentials{
+		tokenURL:     tokenURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		grantType:    grantType,
+		refreshToken: refreshToken,
+		scopes:       scopes,
+		httpClient:   http.DefaultClient,
+		lock:         make(chan struct{}, 1),
+	}
+}
+
+// Headers returns the authentication headers, without any header when no token can be obtained.
+// The client uses HeadersWithContext, which reports the errors.
+func (c *oauth2Credentials) Headers() map[string]string {
+	headers, err := c.HeadersWithContext(context.Background())
+	if err != nil {
+		return map[string]string{}
+	}
+	return headers
+}
+
+// HeadersWithContext returns the authentication headers,
+// obtaining a new access token when the cached one is missing or about to expire.
+func (c *oauth2Credentials) HeadersWithContext(ctx context.Context) (map[string]string, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	if !c.token.valid(time.Now()) {
+		if err := c.fetchToken(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]string{httpHeaderAuthorization: "Bearer " + c.token.AccessToken}, nil
+}
+
+// Refresh obtains a new access token. Concurrent calls result in a single token request.
+func (c *oauth2Credentials) Refresh(ctx context.Context) error {
+	requested := time.Now()
+
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+
+	// another goroutine refreshed the token while we were waiting
+	if c.token != nil && c.token.fetchedAt.After(requested) {
+		return nil
+	}
+	return c.fetchToken(ctx)
+}
+
+func (c *oauth2Credentials) acquire(ctx context.Context) error {
+	select {
+	case c.lock <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *oauth2Credentials) release() {
+	<-c.lock
+}
+
+// fetchToken requests a new access token. It must be called with the lock held.
+func (c *oauth2Credentials) fetchToken(ctx context.Context) error {
+	form := url.Values{}
+	form.Set("grant_type", c.grantType)
+	form.Set("client_id", c.clientID)
+	form.Set("client_secret", c.clientSecret)
+	if c.refreshToken != "" {
+		form.Set("refresh_token", c.refreshToken)
+	}
+	if len(c.scopes) > 0 {
+		form.Set("scope", strings.Join(c.scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorReadSize))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		oauth2Error := &OAuth2Error{}
+		json.Unmarshal(data, oauth2Error)
+		oauth2Error.StatusCode = resp.StatusCode
+		return oauth2Error
+	}
+
+	token := &oauth2Token{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return err
+	}
+	if token.AccessToken == "" {
+		return fmt.Errorf("productplan: oauth2 token response without access_token")
+	}
+
+	token.fetchedAt = time.Now()
+	if token.ExpiresIn > 0 {
+		token.expiry = token.fetchedAt.Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	if token.RefreshToken != "" && c.grantType == "refresh_token" {
+		c.refreshToken = token.RefreshToken
+	}
+
+	c.token = token
+	return nil
+}
+
+// reauthenticate is the middleware refreshing RefreshableCredentials when the API answers 401 Unauthorized,
+// and sending the request once more with the new access token.
+func (c *Client) reauthenticate(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		resp, err := next(req)
+
+		credentials, ok := c.Credentials.(RefreshableCredentials)
+		if !ok || err != nil || resp.StatusCode != http.StatusUnauthorized {
+			return resp, err
+		}
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, err
+		}
+
+		if err := credentials.Refresh(req.Context()); err != nil {
+			return resp, nil
+		}
+		headers, err := credentials.HeadersWithContext(req.Context())
+		if err != nil {
+			return resp, nil
+		}
+		discardBody(resp)
+
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		return next(req)
+	}
+}
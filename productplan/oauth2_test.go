@@ -0,0 +1,188 @@
+package productplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// setupTokenServer serves numbered access tokens, and records the grants it received.
+func setupTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	var grants []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm() returned error: %v", err)
+		}
+
+		if r.PostForm.Get("client_id") != "client-id" || r.PostForm.Get("client_secret") != "client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"error":"invalid_client"}`)
+			return
+		}
+
+		mu.Lock()
+		grants = append(grants, r.PostForm.Get("grant_type")+":"+r.PostForm.Get("refresh_token")+r.PostForm.Get("scope"))
+		n := len(grants)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d,"refresh_token":"refresh-%d"}`, n, expiresIn, n)
+	}))
+
+	return server, &grants
+}
+
+func TestRefreshTokenCredentials(t *testing.T) {
+	tokenServer, grants := setupTokenServer(t, 3600)
+	defer tokenServer.Close()
+
+	credentials := NewRefreshTokenCredentials(tokenServer.URL, "client-id", "client-secret", "refresh-0")
+
+	for i := 0; i < 2; i++ {
+		headers, err := credentials.HeadersWithContext(context.Background())
+		if err != nil {
+			t.Fatalf("HeadersWithContext() returned error: %v", err)
+		}
+		testCredentials(t, credentials, map[string]string{httpHeaderAuthorization: "Bearer token-1"})
+		if headers[httpHeaderAuthorization] != "Bearer token-1" {
+			t.Errorf("HeadersWithContext() returned %v", headers)
+		}
+	}
+
+	if err := credentials.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() returned error: %v", err)
+	}
+	testCredentials(t, credentials, map[string]string{httpHeaderAuthorization: "Bearer token-2"})
+
+	// the rotated refresh token is used for the next refresh
+	want := []string{"refresh_token:refresh-0", "refresh_token:refresh-1"}
+	if fmt.Sprint(*grants) != fmt.Sprint(want) {
+		t.Errorf("token requests %v, want %v", *grants, want)
+	}
+}
+
+func TestClientCredentials_Expiry(t *testing.T) {
+	// tokens expiring within tokenExpiryDelta are never reused
+	tokenServer, grants := setupTokenServer(t, 10)
+	defer tokenServer.Close()
+
+	credentials := NewClientCredentials(tokenServer.URL, "client-id", "client-secret", "read", "write")
+	credentials.Headers()
+	credentials.Headers()
+
+	want := []string{"client_credentials:read write", "client_credentials:read write"}
+	if fmt.Sprint(*grants) != fmt.Sprint(want) {
+		t.Errorf("token requests %v, want %v", *grants, want)
+	}
+}
+
+func TestClientCredentials_Concurrent(t *testing.T) {
+	tokenServer, grants := setupTokenServer(t, 3600)
+	defer tokenServer.Close()
+
+	credentials := NewClientCredentials(tokenServer.URL, "client-id", "client-secret")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			credentials.HeadersWithContext(context.Background())
+		}()
+		go func() {
+			defer wg.Done()
+			credentials.Refresh(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	if len(*grants) > 11 {
+		t.Errorf("sent %v token requests for 10 concurrent refreshes", len(*grants))
+	}
+}
+
+func TestClientCredentials_InvalidClient(t *testing.T) {
+	tokenServer, _ := setupTokenServer(t, 3600)
+	defer tokenServer.Close()
+
+	credentials := NewClientCredentials(tokenServer.URL, "client-id", "wrong-secret")
+
+	_, err := credentials.HeadersWithContext(context.Background())
+
+	var oauth2Error *OAuth2Error
+	if !errors.As(err, &oauth2Error) || oauth2Error.Code != "invalid_client" {
+		t.Fatalf("HeadersWithContext() error = %v, want an invalid_client *OAuth2Error", err)
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("HeadersWithContext() error %v should match %v", err, ErrUnauthorized)
+	}
+}
+
+func TestClient_Do_RefreshOnUnauthorized(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	tokenServer, grants := setupTokenServer(t, 3600)
+	defer tokenServer.Close()
+	client.Credentials = NewRefreshTokenCredentials(tokenServer.URL, "client-id", "client-secret", "refresh-0")
+
+	var authorizations []string
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get(httpHeaderAuthorization))
+
+		// the first token was revoked
+		if r.Header.Get(httpHeaderAuthorization) == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"message":"token expired"}`)
+			return
+		}
+
+		httpResponse := httpResponseFixture(t, "/status/success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	if _, err := client.Status.GetStatus(); err != nil {
+		t.Fatalf("Status.GetStatus() returned error: %v", err)
+	}
+
+	want := []string{"Bearer token-1", "Bearer token-2"}
+	if fmt.Sprint(authorizations) != fmt.Sprint(want) {
+		t.Errorf("Authorization headers sent %v, want %v", authorizations, want)
+	}
+	if len(*grants) != 2 {
+		t.Errorf("sent %v token requests, want %v", len(*grants), 2)
+	}
+}
+
+func TestClient_Do_UnauthorizedAfterRefresh(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	tokenServer, _ := setupTokenServer(t, 3600)
+	defer tokenServer.Close()
+	client.Credentials = NewClientCredentials(tokenServer.URL, "client-id", "client-secret")
+
+	calls := 0
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+		io.WriteString(w, `{"message":"forbidden client"}`)
+	})
+
+	_, err := client.Status.GetStatus()
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Status.GetStatus() error = %v, want %v", err, ErrUnauthorized)
+	}
+	if calls != 2 {
+		t.Errorf("Status.GetStatus() sent %v requests, want %v", calls, 2)
+	}
+}
@@ -0,0 +1,178 @@
+package productplan
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created with NewClient.
+type Option func(*Client)
+
+// defaultTransport returns a copy of the default HTTP transport,
+// so that configuring a client never affects the others.
+func defaultTransport() *http.Transport {
+	return http.DefaultTransport.(*http.Transport).Clone()
+}
+
+// transport returns a copy of the client transport that can be modified,
+// or nil when the client uses a custom http.RoundTripper.
+func (c *Client) transport() *http.Transport {
+	switch tr := c.HTTPClient.Transport.(type) {
+	case nil:
+		return defaultTransport()
+	case *http.Transport:
+		return tr.Clone()
+	}
+	return nil
+}
+
+// setHTTPClient replaces the HTTP client with a copy of httpClient,
+// so that the caller's client is never modified by the other options.
+func (c *Client) setHTTPClient(httpClient *http.Client) {
+	copied := *httpClient
+	c.HTTPClient = &copied
+}
+
+// WithHTTPClient sets the HTTP client used to communicate with the API.
+// A nil httpClient keeps the default client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient == nil {
+			return
+		}
+		c.setHTTPClient(httpClient)
+	}
+}
+
+// WithTransport sets the transport of the HTTP client used to communicate with the API.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.setHTTPClient(c.HTTPClient)
+		c.HTTPClient.Transport = transport
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to communicate with the API.
+// It has no effect when a custom transport other than *http.Transport is used.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Client) {
+		tr := c.transport()
+		if tr == nil {
+			return
+		}
+
+		tr.TLSClientConfig = config.Clone()
+		c.setHTTPClient(c.HTTPClient)
+		c.HTTPClient.Transport = tr
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the server certificates.
+// This is insecure, and should only be used against local stand-ins of the API.
+// It has no effect when a custom transport other than *http.Transport is used.
+func WithInsecureSkipVerify() Option {
+	return func(c *Client) {
+		tr := c.transport()
+		if tr == nil {
+			return
+		}
+
+		if tr.TLSClientConfig == nil {
+			tr.TLSClientConfig = &tls.Config{}
+		}
+		tr.TLSClientConfig.InsecureSkipVerify = true
+		c.setHTTPClient(c.HTTPClient)
+		c.HTTPClient.Transport = tr
+	}
+}
+
+// WithTimeout sets the time limit of each HTTP request sent to the API, including reading the response.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.setHTTPClient(c.HTTPClient)
+		c.HTTPClient.Timeout = timeout
+	}
+}
+
+// WithUserAgent sets a custom user agent, appended to the default one.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithBaseURL overrides the endpoint given to NewClient.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithProfile sets the credentials, and the base URL when the profile has one,
+// eg. the Profile resolved by a CredentialsChain. A nil profile is ignored.
+func WithProfile(profile *Profile) Option {
+	return func(c *Client) {
+		if profile == nil {
+			return
+		}
+		c.Credentials = NewOauthTokenCredentials(profile.Token)
+		if profile.BaseURL != "" {
+			c.BaseURL = profile.BaseURL
+		}
+	}
+}
+
+// WithCredentialsChain sets the chain as the credentials, and sends every request to the base URL
+// of the profile it resolves, when the profile has one, instead of the BaseURL of the client.
+// The token and the base URL of a request always come from the same profile.
+// The chain is resolved by the first request, not by NewClient, using the context of the request.
+func WithCredentialsChain(chain *CredentialsChain) Option {
+	return func(c *Client) {
+		if chain == nil {
+			return
+		}
+
+		c.Credentials = chain
+		c.profileBaseURL = true
+	}
+}
+
+// WithAPIVersion sets the version of the API sent in the X-Api-Version header.
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		c.APIVersion = version
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Client) {
+		c.RetryPolicy = policy
+	}
+}
+
+// WithRateLimiter sets the limiter used to throttle requests.
+// The same limiter can be shared by several clients.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.RateLimiter = limiter
+	}
+}
+
+// WithLogger sets the structured logger receiving the request and response events.
+// sensitiveFields lists additional header and JSON field names to redact.
+func WithLogger(logger *slog.Logger, sensitiveFields ...string) Option {
+	return func(c *Client) {
+		c.Logger = logger
+		c.SensitiveFields = append(c.SensitiveFields, sensitiveFields...)
+	}
+}
+
+// WithCache sets the cache used to send conditional GET requests, see NewMemoryCache and NewDiskCache.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.Cache = cache
+	}
+}
@@ -0,0 +1,93 @@
+package productplan
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewClient_VerifiesCertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/status/success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, NewOauthTokenCredentials("productplan-token"))
+	if _, err := c.Status.GetStatus(); err == nil {
+		t.Errorf("Status.GetStatus() expected a certificate error")
+	}
+
+	c = NewClient(server.URL, NewOauthTokenCredentials("productplan-token"), WithInsecureSkipVerify())
+	if _, err := c.Status.GetStatus(); err != nil {
+		t.Errorf("Status.GetStatus() returned error: %v", err)
+	}
+
+	c = NewClient(server.URL, NewOauthTokenCredentials("productplan-token"), WithHTTPClient(server.Client()))
+	if _, err := c.Status.GetStatus(); err != nil {
+		t.Errorf("Status.GetStatus() returned error: %v", err)
+	}
+}
+
+func TestNewClient_Options(t *testing.T) {
+	httpClient := &http.Client{}
+	policy := DefaultRetryPolicy()
+
+	c := NewClient("localhost", NewOauthTokenCredentials("productplan-token"),
+		WithHTTPClient(httpClient),
+		WithTimeout(5*time.Second),
+		WithBaseURL("https://go.example.com"),
+		WithUserAgent("sync/1.2"),
+		WithAPIVersion("2"),
+		WithRetryPolicy(policy),
+	)
+
+	if c.HTTPClient.Timeout != 5*time.Second {
+		t.Errorf("NewClient HTTPClient.Timeout = %v, want %v", c.HTTPClient.Timeout, 5*time.Second)
+	}
+	if httpClient.Timeout != 0 {
+		t.Errorf("WithTimeout modified the given HTTP client")
+	}
+	if c.RetryPolicy != policy {
+		t.Errorf("NewClient RetryPolicy = %v, want %v", c.RetryPolicy, policy)
+	}
+
+	req, _ := c.NewRequest("GET", "/foo", nil)
+	if got, want := req.URL.String(), "https://go.example.com/foo"; got != want {
+		t.Errorf("NewRequest() URL = %v, want %v", got, want)
+	}
+	if got, want := req.Header.Get("User-Agent"), defaultUserAgent+" sync/1.2"; got != want {
+		t.Errorf("NewRequest() User-Agent = %v, want %v", got, want)
+	}
+	if got, want := req.Header.Get("X-Api-Version"), "2"; got != want {
+		t.Errorf("NewRequest() X-Api-Version = %v, want %v", got, want)
+	}
+}
+
+func TestWithHTTPClient_Nil(t *testing.T) {
+	c := NewClient("localhost", NewOauthTokenCredentials("productplan-token"), WithHTTPClient(nil), WithTimeout(5*time.Second))
+
+	if c.HTTPClient == nil || c.HTTPClient.Timeout != 5*time.Second {
+		t.Errorf("NewClient HTTPClient = %+v, want the default client", c.HTTPClient)
+	}
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	c := NewClient("localhost", NewOauthTokenCredentials("productplan-token"), WithTLSConfig(config))
+
+	tr, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("NewClient Transport is %T", c.HTTPClient.Transport)
+	}
+	if tr.TLSClientConfig.MinVersion != tls.VersionTLS12 || tr.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("NewClient TLSClientConfig = %+v", tr.TLSClientConfig)
+	}
+	if http.DefaultTransport.(*http.Transport).TLSClientConfig == tr.TLSClientConfig {
+		t.Errorf("WithTLSConfig modified the default transport")
+	}
+}
@@ -0,0 +1,143 @@
+package productplan
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Cursor represents a keyset pagination cursor,
+// as returned by the API in the Link header of list responses.
+//
+// A cursor is serialized as a comma-separated list of key=value pairs,
+// eg. by=id,from=7302,items=500
+type Cursor struct {
+	// The field the results are keyed by.
+	By string
+
+	// The key to start after. Empty for the first page.
+	From string
+
+	// The number of entries per page.
+	Items int
+}
+
+// ParseCursor parses the serialized form of a Cursor.
+func ParseCursor(s string) (*Cursor, error) {
+	cursor := &Cursor{}
+
+	for _, part := range strings.Split(s, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid pagination cursor %q", s)
+		}
+
+		switch kv[0] {
+		case "by":
+			cursor.By = kv[1]
+		case "from":
+			cursor.From = kv[1]
+		case "items":
+			items, err := strconv.Atoi(kv[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid pagination cursor %q: %v", s, err)
+			}
+			cursor.Items = items
+		default:
+			return nil, fmt.Errorf("invalid pagination cursor %q: unknown key %q", s, kv[0])
+		}
+	}
+
+	return cursor, nil
+}
+
+// String returns the serialized form of the cursor, as expected by the API.
+func (c *Cursor) String() string {
+	var parts []string
+	if c.By != "" {
+		parts = append(parts, "by="+c.By)
+	}
+	if c.From != "" {
+		parts = append(parts, "from="+c.From)
+	}
+	if c.Items > 0 {
+		parts = append(parts, "items="+strconv.Itoa(c.Items))
+	}
+	return strings.Join(parts, ",")
+}
+
+// EncodeValues implements the query.Encoder interface,
+// so that a Cursor can be passed in the ListOptions.
+func (c *Cursor) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, c.String())
+	return nil
+}
+
+// parseLinkHeader parses a Link header (RFC 8288) into a map of URLs by relation type.
+func parseLinkHeader(header string) map[string]string {
+	links := make(map[string]string)
+
+	for _, link := range strings.Split(header, ",") {
+		segments := strings.Split(link, ";")
+		if len(segments) < 2 {
+			continue
+		}
+
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		target = strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+
+		for _, param := range segments[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) != 2 || kv[0] != "rel" {
+				continue
+			}
+			for _, rel := range strings.Fields(strings.Trim(kv[1], `"`)) {
+				links[rel] = target
+			}
+		}
+	}
+
+	return links
+}
+
+// cursorFromLink extracts the pagination cursor from a link target.
+func cursorFromLink(link string) *Cursor {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil
+	}
+
+	cursor, err := ParseCursor(u.Query().Get("pagination"))
+	if err != nil {
+		return nil
+	}
+	return cursor
+}
+
+// newPagination builds the Pagination of a response from its Link header.
+// It returns nil when the response is not paginated.
+func newPagination(resp *http.Response) *Pagination {
+	header := resp.Header.Get("Link")
+	if header == "" {
+		return nil
+	}
+
+	pagination := &Pagination{}
+	for rel, link := range parseLinkHeader(header) {
+		switch rel {
+		case "next":
+			pagination.Next = cursorFromLink(link)
+		case "prev":
+			pagination.Prev = cursorFromLink(link)
+		case "first":
+			pagination.First = cursorFromLink(link)
+		}
+	}
+
+	return pagination
+}
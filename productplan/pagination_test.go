@@ -0,0 +1,60 @@
+package productplan
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseCursor(t *testing.T) {
+	got, err := ParseCursor("by=id,from=7302,items=500")
+	if err != nil {
+		t.Fatalf("ParseCursor() returned error: %v", err)
+	}
+
+	want := &Cursor{By: "id", From: "7302", Items: 500}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseCursor() returned %+v, want %+v", got, want)
+	}
+
+	if s := got.String(); s != "by=id,from=7302,items=500" {
+		t.Errorf("Cursor.String() returned %v", s)
+	}
+
+	for _, invalid := range []string{"", "by", "items=lots", "page=2"} {
+		if _, err := ParseCursor(invalid); err == nil {
+			t.Errorf("ParseCursor(%q) expected an error", invalid)
+		}
+	}
+}
+
+func TestParseLinkHeader(t *testing.T) {
+	header := `</api/roadmaps/7302/bars?pagination=by%3Did%2Cfrom%3D262149%2Citems%3D500>; rel="next", ` +
+		`</api/roadmaps/7302/bars?pagination=by%3Did%2Citems%3D500>; rel="first"`
+
+	got := parseLinkHeader(header)
+	want := map[string]string{
+		"next":  "/api/roadmaps/7302/bars?pagination=by%3Did%2Cfrom%3D262149%2Citems%3D500",
+		"first": "/api/roadmaps/7302/bars?pagination=by%3Did%2Citems%3D500",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLinkHeader() returned %+v, want %+v", got, want)
+	}
+}
+
+func TestAddURLQueryOptions_Cursor(t *testing.T) {
+	options := &ListOptions{Filters: "name=Roadmap10", Cursor: &Cursor{By: "id", From: "7302", Items: 500}}
+
+	path, err := addURLQueryOptions("/api/roadmaps", options)
+	if err != nil {
+		t.Fatalf("addURLQueryOptions() returned error: %v", err)
+	}
+
+	u, _ := url.Parse(path)
+	if got := u.Query().Get("pagination"); got != "by=id,from=7302,items=500" {
+		t.Errorf("addURLQueryOptions() pagination = %v", got)
+	}
+	if got := u.Query().Get("filters"); got != "name=Roadmap10" {
+		t.Errorf("addURLQueryOptions() filters = %v", got)
+	}
+}
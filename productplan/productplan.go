@@ -2,11 +2,12 @@ package productplan
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -40,27 +41,69 @@ type Client struct {
 	// UserAgent used when communicating with the Productplan API.
 	UserAgent string
 
-	Status   *StatusService
-	Ideas    *IdeasService
-	Roadmaps *RoadmapsService
-	Bars     *BarsService
+	// APIVersion sent in the X-Api-Version header of every request.
+	APIVersion string
 
-	// Set to true to output debugging logs during API calls
+	// RetryPolicy used to retry failed requests. When nil, requests are sent only once.
+	RetryPolicy *RetryPolicy
+
+	// RateLimiter used to throttle requests. When nil, requests are not throttled.
+	RateLimiter *RateLimiter
+
+	// Cache used to send conditional GET requests based on the ETag of the responses.
+	// When nil, responses are not cached.
+	Cache Cache
+
+	Status       *StatusService
+	Ideas        *IdeasService
+	Roadmaps     *RoadmapsService
+	Bars         *BarsService
+	CustomFields *CustomFieldsService
+	Lanes        *LanesService
+	Legends      *LegendsService
+
+	// Logger receives the request and response events, nil disables logging.
+	// Bodies are logged at LevelTrace only.
+	Logger *slog.Logger
+
+	// SensitiveFields lists additional header and JSON field names to redact from the logs.
+	// The Authorization header and the OAuth secrets are always redacted.
+	SensitiveFields []string
+
+	// Set to true to output debugging logs during API calls, when no Logger is set
 	Debug bool
+
+	// Middlewares wrap every request sent to the API, see Use.
+	Middlewares []Middleware
+
+	// profileBaseURL sends the requests to the base URL of the profile of the CredentialsChain,
+	// see WithCredentialsChain.
+	profileBaseURL bool
 }
 
 // NewClient returns a new ProductPlan API client using the given credentials.
-func NewClient(endpoint string, credentials Credentials) *Client {
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+//
+// The client verifies the server certificates. Options are applied in order,
+// eg. NewClient(url, credentials, WithTimeout(30*time.Second), WithUserAgent("sync/1.2"))
+func NewClient(endpoint string, credentials Credentials, options ...Option) *Client {
+	c := &Client{
+		Credentials: credentials,
+		HTTPClient:  &http.Client{Transport: defaultTransport()},
+		BaseURL:     endpoint,
+		APIVersion:  apiVersion,
 	}
-
-	c := &Client{Credentials: credentials, HTTPClient: &http.Client{Transport: tr}, BaseURL: endpoint}
 	c.Status = &StatusService{client: c}
 	c.Ideas = &IdeasService{client: c}
 	c.Roadmaps = &RoadmapsService{client: c}
 	c.Bars = &BarsService{client: c}
+	c.CustomFields = &CustomFieldsService{client: c}
+	c.Lanes = &LanesService{client: c}
+	c.Legends = &LegendsService{client: c}
 	c.Debug = false
+
+	for _, option := range options {
+		option(c)
+	}
 	return c
 }
 
@@ -68,7 +111,17 @@ func NewClient(endpoint string, credentials Credentials) *Client {
 // The path is expected to be a relative path and will be resolved
 // according to the BaseURL of the Client. Paths should always be specified without a preceding slash.
 func (c *Client) NewRequest(method, path string, payload interface{}) (*http.Request, error) {
-	url := c.BaseURL + path
+	return c.NewRequestWithContext(context.Background(), method, path, payload)
+}
+
+// NewRequestWithContext creates an API request bound to ctx.
+// Cancelling ctx, or reaching its deadline, aborts the request once it is sent with Do.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, path string, payload interface{}) (*http.Request, error) {
+	baseURL, headers, err := c.endpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	url := baseURL + path
 
 	body := new(bytes.Buffer)
 	if payload != nil {
@@ -78,24 +131,60 @@ func (c *Client) NewRequest(method, path string, payload interface{}) (*http.Req
 		}
 	}
 
-	req, err := http.NewRequest(method, url, body)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("X-Api-Version", apiVersion)
+	version := c.APIVersion
+	if version == "" {
+		version = apiVersion
+	}
+
+	req.Header.Set("X-Api-Version", version)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("User-Agent", formatUserAgent(c.UserAgent))
-	for key, value := range c.Credentials.Headers() {
+	for key, value := range headers {
 		req.Header.Add(key, value)
 	}
 
 	return req, nil
 }
 
-func (c *Client) get(path string, obj interface{}) (*http.Response, error) {
-	req, err := c.NewRequest("GET", path, nil)
+// endpoint returns the base URL of a request, and its authentication headers.
+// With WithCredentialsChain, both come from the profile of the chain, so that a token is
+// never sent to the base URL of another profile.
+func (c *Client) endpoint(ctx context.Context) (string, map[string]string, error) {
+	chain, ok := c.Credentials.(*CredentialsChain)
+	if !ok || !c.profileBaseURL {
+		headers, err := credentialsHeaders(ctx, c.Credentials)
+		return c.BaseURL, headers, err
+	}
+
+	profile, err := chain.Resolve(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	baseURL := c.BaseURL
+	if profile.BaseURL != "" {
+		baseURL = profile.BaseURL
+	}
+	return baseURL, NewOauthTokenCredentials(profile.Token).Headers(), nil
+}
+
+func (c *Client) get(ctx context.Context, path string, obj interface{}) (*http.Response, error) {
+	req, err := c.NewRequestWithContext(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(req, obj)
+}
+
+func (c *Client) post(ctx context.Context, path string, payload, obj interface{}) (*http.Response, error) {
+	req, err := c.NewRequestWithContext(ctx, "POST", path, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -103,8 +192,8 @@ func (c *Client) get(path string, obj interface{}) (*http.Response, error) {
 	return c.Do(req, obj)
 }
 
-func (c *Client) post(path string, payload, obj interface{}) (*http.Response, error) {
-	req, err := c.NewRequest("POST", path, payload)
+func (c *Client) patch(ctx context.Context, path string, payload, obj interface{}) (*http.Response, error) {
+	req, err := c.NewRequestWithContext(ctx, "PATCH", path, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -112,8 +201,8 @@ func (c *Client) post(path string, payload, obj interface{}) (*http.Response, er
 	return c.Do(req, obj)
 }
 
-func (c *Client) patch(path string, payload, obj interface{}) (*http.Response, error) {
-	req, err := c.NewRequest("PATCH", path, payload)
+func (c *Client) delete(ctx context.Context, path string, obj interface{}) (*http.Response, error) {
+	req, err := c.NewRequestWithContext(ctx, "DELETE", path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -127,21 +216,19 @@ func (c *Client) patch(path string, payload, obj interface{}) (*http.Response, e
 // or returned as an error if an API error has occurred.
 // If obj implements the io.Writer interface, the raw response body will be written to obj,
 // without attempting to decode it.
+//
+// The request is bound to the context it was created with (see NewRequestWithContext).
+// If that context is cancelled or its deadline is exceeded, the context error is returned.
+//
+// Requests failing with a transient error are retried according to the RetryPolicy of the Client.
+// Every attempt goes through the Middlewares of the Client, then waits for its RateLimiter, if any.
 func (c *Client) Do(req *http.Request, obj interface{}) (*http.Response, error) {
-	if c.Debug {
-		log.Printf("Executing request (%v): %#v", req.URL, req)
-	}
-
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if c.Debug {
-		log.Printf("Response received: %#v", resp)
-	}
-
 	err = CheckResponse(resp)
 	if err != nil {
 		return resp, err
@@ -160,6 +247,24 @@ func (c *Client) Do(req *http.Request, obj interface{}) (*http.Response, error)
 	return resp, err
 }
 
+// send sends an API request through the middleware chain of the client.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	return c.handler()(req)
+}
+
+// roundTrip sends a request with the HTTP client, at the end of the middleware chain.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		// prefer the context error, it is more useful than the wrapped url.Error
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
 // A Response represents an API response.
 type Response struct {
 	HTTPResponse *http.Response
@@ -171,7 +276,7 @@ type Response struct {
 // ListOptions contains the common options you can pass to a List method
 // in order to control parameters such as paginations and page number.
 type ListOptions struct {
-	// Limit query given by string.
+	// Limit query given by string, see SetFilter to build it from a typed Filter.
 	Filters string `url:"filters"`
 
 	// The page to return
@@ -183,7 +288,11 @@ type ListOptions struct {
 	// The order criteria to sort the results.
 	// The value is a comma-separated list of field[:direction],
 	// eg. name | name:desc | name:desc,expiration:desc
+	// See SetOrder to build it from typed fields and directions.
 	Order string `url:"order,omitempty"`
+
+	// The cursor of the page to return, as found in the Pagination of a previous response.
+	Cursor *Cursor `url:"pagination,omitempty"`
 }
 
 // Pagination If the response is paginated, Pagination represents the pagination information.
@@ -192,38 +301,81 @@ type Pagination struct {
 	PerPage      int `json:"per_page"`
 	TotalPages   int `json:"total_pages"`
 	TotalEntries int `json:"total_entries"`
+
+	// Cursors parsed from the Link header of the response.
+	// Next is nil on the last page.
+	Next  *Cursor `json:"-"`
+	Prev  *Cursor `json:"-"`
+	First *Cursor `json:"-"`
 }
 
 // An ErrorResponse represents an API response that generated an error.
+//
+// It matches the sentinel errors (ErrNotFound, ErrUnauthorized, ...) of its status code with errors.Is.
+// 422 and 429 responses are returned as a *ValidationError and a *RateLimitError respectively,
+// both of which wrap an ErrorResponse.
 type ErrorResponse struct {
 	Response
 	Message string `json:"message"`
+
+	// StatusCode of the response.
+	StatusCode int `json:"-"`
+
+	// RequestID is the X-Request-Id of the response, useful when contacting the Productplan support.
+	RequestID string `json:"-"`
+
+	// Body is the raw response body, truncated to maxErrorBodySize bytes.
+	Body string `json:"-"`
 }
 
 // Error implements the error interface.
 func (r *ErrorResponse) Error() string {
+	message := r.Message
+	if message == "" {
+		message = http.StatusText(r.StatusCode)
+	}
+
 	return fmt.Sprintf("%v %v: %v %v",
 		r.HTTPResponse.Request.Method, r.HTTPResponse.Request.URL,
-		r.HTTPResponse.StatusCode, r.Message)
+		r.StatusCode, message)
+}
+
+// Is reports whether the error matches the sentinel error of its status code.
+func (r *ErrorResponse) Is(target error) bool {
+	sentinel, ok := statusErrors[r.StatusCode]
+	return ok && target == sentinel
 }
 
 // CheckResponse checks the API response for errors, and returns them if present.
 // A response is considered an error if the status code is different than 2xx. Specific requests
 // may have additional requirements, but this is sufficient in most of the cases.
+//
+// The error body is decoded when it is JSON. Other bodies, such as the HTML pages
+// of the front end proxies, are kept as is in ErrorResponse.Body.
 func CheckResponse(resp *http.Response) error {
 	if code := resp.StatusCode; 200 <= code && code <= 299 {
 		return nil
 	}
 
-	errorResponse := &ErrorResponse{}
+	errorResponse := &ErrorResponse{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
 	errorResponse.HTTPResponse = resp
 
-	err := json.NewDecoder(resp.Body).Decode(errorResponse)
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorReadSize))
 	if err != nil {
 		return err
 	}
+	errorResponse.Body = truncate(string(data), maxErrorBodySize)
+
+	// a body that is not JSON is not an error in itself, the status code tells what happened
+	var body errorBody
+	if json.Unmarshal(data, &body) == nil {
+		errorResponse.Message = body.Message
+	}
 
-	return errorResponse
+	return newStatusError(errorResponse, body, resp)
 }
 
 // formatUserAgent builds the final user agent to use for HTTP requests.
@@ -2,6 +2,7 @@ package productplan
 
 import (
 	"bufio"
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -101,3 +102,36 @@ func TestClient_NewRequest(t *testing.T) {
 		t.Errorf("NewRequest() X-Api-Version = %v, want %v", v, apiVersion)
 	}
 }
+
+func TestClient_NewRequestWithContext(t *testing.T) {
+	c := NewClient("https://go.example.com", NewOauthTokenCredentials("productplan-token"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := c.NewRequestWithContext(ctx, "GET", "/foo", nil)
+	if err != nil {
+		t.Fatalf("NewRequestWithContext() returned error: %v", err)
+	}
+
+	if req.Context() != ctx {
+		t.Errorf("NewRequestWithContext() context = %v, want %v", req.Context(), ctx)
+	}
+}
+
+func TestClient_Do_ContextCanceled(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request should not reach the server")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.Status.GetStatusWithContext(ctx)
+	if err != context.Canceled {
+		t.Errorf("Status.GetStatusWithContext() error = %v, want %v", err, context.Canceled)
+	}
+}
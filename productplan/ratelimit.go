@@ -0,0 +1,175 @@
+package productplan
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Rate-limit headers returned by the API.
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// RateLimiter is a token bucket limiting the rate of requests sent by a Client.
+//
+// The bucket holds up to burst tokens and is refilled at the configured rate.
+// It also adapts to the rate-limit headers returned by the API:
+// it never hands out more tokens than the server reports as remaining,
+// and it pauses until the reset time once the server quota is exhausted.
+//
+// A RateLimiter is safe for concurrent use, and can be shared by several clients.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	rate   float64
+	burst  int
+	tokens float64
+	last   time.Time
+
+	// pausedUntil is set when the server reports an exhausted quota
+	pausedUntil time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing requestsPerSecond requests on average,
+// with bursts of up to burst requests.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request can be sent, or until ctx is done.
+// It returns the context error if ctx is done first.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		l.mu.Lock()
+		delay := l.reserve(time.Now())
+		l.mu.Unlock()
+
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// rateLimit is the middleware waiting for the RateLimiter of the client before sending a request,
+// and adapting it to the response.
+func (c *Client) rateLimit(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		if err := c.RateLimiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		resp, err := next(req)
+		if err == nil {
+			c.RateLimiter.update(resp)
+		}
+		return resp, err
+	}
+}
+
+// reserve takes a token from the bucket, or returns how long to wait
+// before trying again. It must be called with l.mu held.
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(float64(l.burst), l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	if l.rate <= 0 {
+		// no refill, wait for the server reset
+		return time.Second
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// update adapts the limiter to the rate-limit information of an API response.
+func (l *RateLimiter) update(resp *http.Response) {
+	if l == nil || resp == nil {
+		return
+	}
+
+	now := time.Now()
+	limit := parseRateLimit(resp.Header, now)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if limit.Remaining >= 0 && float64(limit.Remaining) < l.tokens {
+		l.tokens = float64(limit.Remaining)
+	}
+	if limit.Remaining == 0 && limit.Reset.After(l.pausedUntil) {
+		l.pausedUntil = limit.Reset
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok && now.Add(wait).After(l.pausedUntil) {
+			l.pausedUntil = now.Add(wait)
+		}
+	}
+}
+
+// RateLimit represents the rate-limit information returned by the API.
+// Fields are set to -1, or the zero time, when the header is missing.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// parseRateLimit extracts the rate-limit information from the response headers.
+// The reset header is accepted either as a Unix timestamp or as a number of seconds from now.
+func parseRateLimit(header http.Header, now time.Time) RateLimit {
+	limit := RateLimit{Limit: -1, Remaining: -1}
+
+	if v, err := strconv.Atoi(header.Get(headerRateLimitLimit)); err == nil {
+		limit.Limit = v
+	}
+	if v, err := strconv.Atoi(header.Get(headerRateLimitRemaining)); err == nil {
+		limit.Remaining = v
+	}
+	if v, err := strconv.ParseInt(header.Get(headerRateLimitReset), 10, 64); err == nil && v >= 0 {
+		// anything before 2001 can only be a delay
+		if v > 1e9 {
+			limit.Reset = time.Unix(v, 0)
+		} else {
+			limit.Reset = now.Add(time.Duration(v) * time.Second)
+		}
+	}
+
+	return limit
+}
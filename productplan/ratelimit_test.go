@@ -0,0 +1,110 @@
+package productplan
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := NewRateLimiter(20, 2)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("RateLimiter.Wait() returned error: %v", err)
+		}
+	}
+
+	// 2 tokens from the burst, then 2 more at 20 per second
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("RateLimiter.Wait() let 4 requests through in %v, want at least 100ms", elapsed)
+	}
+}
+
+func TestRateLimiter_Wait_ContextCanceled(t *testing.T) {
+	limiter := NewRateLimiter(0.01, 1)
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("RateLimiter.Wait() returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("RateLimiter.Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRateLimiter_Update(t *testing.T) {
+	limiter := NewRateLimiter(100, 10)
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+
+	resp := &http.Response{StatusCode: 200, Header: http.Header{}}
+	resp.Header.Set(headerRateLimitLimit, "100")
+	resp.Header.Set(headerRateLimitRemaining, "0")
+	resp.Header.Set(headerRateLimitReset, strconv.FormatInt(reset.Unix(), 10))
+	limiter.update(resp)
+
+	if limiter.tokens != 0 {
+		t.Errorf("RateLimiter tokens = %v, want %v", limiter.tokens, 0)
+	}
+	if !limiter.pausedUntil.Equal(reset) {
+		t.Errorf("RateLimiter pausedUntil = %v, want %v", limiter.pausedUntil, reset)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("RateLimiter.Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Date(2018, 7, 11, 7, 51, 5, 0, time.UTC)
+
+	header := http.Header{}
+	header.Set(headerRateLimitLimit, "500")
+	header.Set(headerRateLimitRemaining, "12")
+	header.Set(headerRateLimitReset, "30")
+
+	got := parseRateLimit(header, now)
+	want := RateLimit{Limit: 500, Remaining: 12, Reset: now.Add(30 * time.Second)}
+	if got != want {
+		t.Errorf("parseRateLimit() returned %+v, want %+v", got, want)
+	}
+
+	got = parseRateLimit(http.Header{}, now)
+	want = RateLimit{Limit: -1, Remaining: -1}
+	if got != want {
+		t.Errorf("parseRateLimit() returned %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_Do_RateLimited(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	client.RateLimiter = NewRateLimiter(0.01, 1)
+
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/status/success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	if _, err := client.Status.GetStatus(); err != nil {
+		t.Fatalf("Status.GetStatus() returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.Status.GetStatusWithContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Status.GetStatusWithContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
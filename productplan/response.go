@@ -0,0 +1,75 @@
+package productplan
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// newResponse wraps an HTTP response, filling in the pagination information.
+func newResponse(resp *http.Response) Response {
+	response := Response{HTTPResponse: resp}
+	if resp != nil {
+		response.Pagination = newPagination(resp)
+	}
+	return response
+}
+
+// StatusCode returns the HTTP status code of the response, or 0 if there is no response.
+func (r *Response) StatusCode() int {
+	if r.HTTPResponse == nil {
+		return 0
+	}
+	return r.HTTPResponse.StatusCode
+}
+
+// header returns the value of a response header.
+func (r *Response) header(name string) string {
+	if r.HTTPResponse == nil {
+		return ""
+	}
+	return r.HTTPResponse.Header.Get(name)
+}
+
+// RequestID returns the X-Request-Id of the response, to be quoted when contacting the Productplan support.
+func (r *Response) RequestID() string {
+	return r.header("X-Request-Id")
+}
+
+// ETag returns the ETag of the response.
+func (r *Response) ETag() string {
+	return r.header("ETag")
+}
+
+// RateLimit returns the rate-limit information of the response.
+func (r *Response) RateLimit() RateLimit {
+	if r.HTTPResponse == nil {
+		return RateLimit{Limit: -1, Remaining: -1}
+	}
+	return parseRateLimit(r.HTTPResponse.Header, time.Now())
+}
+
+// ListResponse represents a response from an API method that returns a list.
+// The items are the plain models, eg. Bar: the response metadata is on the list only.
+// The Pagination holds the cursor of the next page, if any.
+type ListResponse[T any] struct {
+	Response
+	Items []T
+}
+
+// getList gets a list endpoint, with the options as URL query parameters.
+func getList[T any](ctx context.Context, c *Client, path string, options interface{}) (*ListResponse[T], error) {
+	path, err := addURLQueryOptions(path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	listResponse := &ListResponse[T]{}
+	resp, err := c.get(ctx, path, &listResponse.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	listResponse.Response = newResponse(resp)
+	return listResponse, nil
+}
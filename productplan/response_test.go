@@ -0,0 +1,59 @@
+package productplan
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestResponse_Metadata(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/bars", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/bars/list_bars_with_name_filter.http")
+
+		for _, name := range []string{"X-Request-Id", "Etag", "Link"} {
+			w.Header().Set(name, httpResponse.Header.Get(name))
+		}
+		w.Header().Set(headerRateLimitLimit, "500")
+		w.Header().Set(headerRateLimitRemaining, "499")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	barsResponse, err := client.Bars.ListBars(&ListOptions{Filters: "name=APIBar1001"})
+	if err != nil {
+		t.Fatalf("Bars.ListBars() returned error: %v", err)
+	}
+
+	if got := barsResponse.StatusCode(); got != http.StatusOK {
+		t.Errorf("StatusCode() returned %v, want %v", got, http.StatusOK)
+	}
+	if got := barsResponse.RequestID(); got == "" {
+		t.Errorf("RequestID() returned an empty request ID")
+	}
+	if got := barsResponse.ETag(); got == "" {
+		t.Errorf("ETag() returned an empty ETag")
+	}
+	if got := barsResponse.RateLimit(); got.Limit != 500 || got.Remaining != 499 {
+		t.Errorf("RateLimit() returned %+v", got)
+	}
+
+	wantNext := &Cursor{By: "id", From: "205414", Items: 500}
+	if got := barsResponse.Pagination.Next; !reflect.DeepEqual(got, wantNext) {
+		t.Errorf("Pagination.Next returned %+v, want %+v", got, wantNext)
+	}
+}
+
+func TestResponse_Empty(t *testing.T) {
+	response := &Response{}
+
+	if got := response.StatusCode(); got != 0 {
+		t.Errorf("StatusCode() returned %v, want 0", got)
+	}
+	if got := response.RequestID(); got != "" {
+		t.Errorf("RequestID() returned %v, want empty", got)
+	}
+}
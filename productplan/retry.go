@@ -0,0 +1,180 @@
+package productplan
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const ideasImportPath = "/api/ideas/actions/import"
+
+// RetryPolicy configures how Client.Do retries requests that failed
+// because of a transport error or a transient API response (429, 502, 503, 504).
+//
+// Only idempotent requests are retried. GET, HEAD, OPTIONS, PUT, PATCH and DELETE
+// are considered idempotent; POST requests are never retried,
+// unless IdempotentImport is set for the ideas import endpoint.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for a request, including the first one.
+	MaxAttempts int
+
+	// MinBackoff is the base delay before the first retry.
+	// The delay doubles on each subsequent retry.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts.
+	// When the server asks for a longer wait via Retry-After, the request is not retried.
+	MaxBackoff time.Duration
+
+	// IdempotentImport marks POST /api/ideas/actions/import as safe to retry.
+	IdempotentImport bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for most clients.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+	}
+}
+
+// retry is the outermost middleware, it sends the request again as long as the RetryPolicy allows.
+func (c *Client) retry(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		logger := c.logger()
+
+		for attempt := 1; ; attempt++ {
+			resp, err := next(withAttempt(req, attempt))
+
+			if !c.RetryPolicy.shouldRetry(req, resp, err, attempt) {
+				return resp, err
+			}
+
+			delay, ok := c.RetryPolicy.backoff(attempt, resp)
+			if !ok {
+				return resp, err
+			}
+			discardBody(resp)
+			c.logRetry(logger, req, attempt+1, delay)
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-req.Context().Done():
+				timer.Stop()
+				return nil, req.Context().Err()
+			case <-timer.C:
+			}
+
+			// the previous attempt consumed the body, rewind it
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = body
+			}
+		}
+	}
+}
+
+// isRetryableStatus reports whether a response status code is worth retrying.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotent reports whether req can safely be sent more than once.
+func (p *RetryPolicy) isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "PATCH", "DELETE":
+		return true
+	case "POST":
+		return p.IdempotentImport && req.URL.Path == ideasImportPath
+	}
+	return false
+}
+
+// shouldRetry reports whether a request should be sent again after the given attempt.
+func (p *RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts || !p.isIdempotent(req) {
+		return false
+	}
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+
+	return isRetryableStatus(resp.StatusCode)
+}
+
+// backoff returns the delay to wait before the next attempt,
+// and false when the request should not be retried at all.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+				return 0, false
+			}
+			return wait, true
+		}
+	}
+
+	delay := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0, true
+	}
+
+	// jitter the delay within [delay/2, delay]
+	half := int64(delay / 2)
+	return time.Duration(half + rand.Int63n(half+1)), true
+}
+
+// parseRetryAfter parses a Retry-After header value,
+// given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// discardBody drains and closes a response body that is not going to be used,
+// so that the underlying connection can be reused.
+func discardBody(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096))
+	resp.Body.Close()
+}
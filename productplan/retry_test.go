@@ -0,0 +1,196 @@
+package productplan
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+}
+
+func TestClient_Do_RetriesTransientErrors(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	client.RetryPolicy = testRetryPolicy()
+
+	calls := 0
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			io.WriteString(w, `{"message":"bad gateway"}`)
+			return
+		}
+
+		httpResponse := httpResponseFixture(t, "/status/success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	statusResponse, err := client.Status.GetStatus()
+	if err != nil {
+		t.Fatalf("Status.GetStatus() returned error: %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("Status.GetStatus() sent %v requests, want %v", calls, 3)
+	}
+	if statusResponse.Status.Application != "up" {
+		t.Errorf("statusResponse.Status.Application returned %+v", statusResponse.Status.Application)
+	}
+}
+
+func TestClient_Do_RetriesGiveUp(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	client.RetryPolicy = testRetryPolicy()
+
+	calls := 0
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		io.WriteString(w, `{"message":"unavailable"}`)
+	})
+
+	_, err := client.Status.GetStatus()
+	if err == nil {
+		t.Fatalf("Status.GetStatus() expected an error")
+	}
+
+	if calls != 3 {
+		t.Errorf("Status.GetStatus() sent %v requests, want %v", calls, 3)
+	}
+}
+
+func TestClient_Do_RetryAfter(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	client.RetryPolicy = testRetryPolicy()
+	client.RetryPolicy.MaxBackoff = 2 * time.Second
+
+	var first time.Time
+	calls := 0
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			io.WriteString(w, `{"message":"slow down"}`)
+			return
+		}
+
+		if waited := time.Since(first); waited < time.Second {
+			t.Errorf("retry sent after %v, want at least 1s", waited)
+		}
+
+		httpResponse := httpResponseFixture(t, "/status/success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	if _, err := client.Status.GetStatus(); err != nil {
+		t.Fatalf("Status.GetStatus() returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Status.GetStatus() sent %v requests, want %v", calls, 2)
+	}
+}
+
+func TestClient_Do_RetryAfterAboveMaxBackoff(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	client.RetryPolicy = testRetryPolicy()
+
+	calls := 0
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+		io.WriteString(w, `{"message":"slow down"}`)
+	})
+
+	if _, err := client.Status.GetStatus(); err == nil {
+		t.Fatalf("Status.GetStatus() expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("Status.GetStatus() sent %v requests, want %v", calls, 1)
+	}
+}
+
+func TestClient_Do_ImportRetries(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	client.RetryPolicy = testRetryPolicy()
+
+	calls := 0
+	mux.HandleFunc("/api/ideas/actions/import", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if len(body) == 0 {
+			t.Errorf("request %v was sent without a body", calls)
+		}
+
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			io.WriteString(w, `{"message":"bad gateway"}`)
+			return
+		}
+
+		httpResponse := httpResponseFixture(t, "/ideas_import/idea_import_success.http")
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	ideas := IdeasImportAttributes{
+		IdeaImportRoadmap: IdeaImportRoadmap{ID: 4946},
+		Ideas:             []Ideas{{Name: "Product Research"}},
+	}
+
+	// not idempotent by default
+	if _, err := client.Ideas.Import(ideas); err == nil {
+		t.Fatalf("Ideas.Import() expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("Ideas.Import() sent %v requests, want %v", calls, 1)
+	}
+
+	calls = 0
+	client.RetryPolicy.IdempotentImport = true
+
+	if _, err := client.Ideas.Import(ideas); err != nil {
+		t.Fatalf("Ideas.Import() returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Ideas.Import() sent %v requests, want %v", calls, 2)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2018, 7, 11, 7, 51, 5, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"Wed, 11 Jul 2018 07:51:35 GMT", 30 * time.Second, true},
+		{"Wed, 11 Jul 2018 07:50:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -18,6 +18,8 @@ type RoadmapLinks struct {
 	Bars         map[string]string `json:"bars,omitempty"`
 	Ideas        map[string]string `json:"ideas,omitempty"`
 	CustomFields map[string]string `json:"custom_fields,omitempty"`
+	Lanes        map[string]string `json:"lanes,omitempty"`
+	Legends      map[string]string `json:"legends,omitempty"`
 }
 
 // RoadmapListOptions specifies optional parameters to pass to Roadmaps.ListRoadmaps method
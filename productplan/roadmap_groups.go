@@ -0,0 +1,121 @@
+package productplan
+
+import (
+	"context"
+	"fmt"
+)
+
+// roadmapGroup is a named group of bars of a roadmap, a Lane or a Legend,
+// held by name in a field of the bars and ideas.
+type roadmapGroup interface {
+	groupID() int
+	groupName() string
+}
+
+// groupKind describes a kind of roadmapGroup: where to list them,
+// and the field of the bars and ideas holding them.
+type groupKind[T roadmapGroup] struct {
+	// field of the bars and ideas, eg. pp_lanes
+	field string
+
+	// segment of the list path, eg. /api/roadmaps/1/lanes
+	segment string
+
+	// link of the roadmap to the list, if any
+	link func(links RoadmapLinks) map[string]string
+
+	// errNotFound is wrapped when a bar is moved to a group not on its roadmap
+	errNotFound error
+}
+
+// list gets a page of the groups of a roadmap, following the link of the roadmap when available.
+func (k groupKind[T]) list(ctx context.Context, c *Client, roadmap Roadmap, options *ListOptions) (*ListResponse[T], error) {
+	path := k.link(roadmap.RoadmapLinks)["href"]
+	if path == "" {
+		path = fmt.Sprintf("/api/roadmaps/%v/%v", roadmap.ID, k.segment)
+	}
+	return getList[T](ctx, c, path, options)
+}
+
+// iter iterates over all the groups of a roadmap.
+func (k groupKind[T]) iter(ctx context.Context, c *Client, roadmap Roadmap, options *ListOptions) *Iterator[T] {
+	return newIterator(ctx, func(ctx context.Context, cursor *Cursor) (*ListResponse[T], error) {
+		return k.list(ctx, c, roadmap, withCursor(options, cursor))
+	})
+}
+
+// all fetches every group of the roadmap of a bar.
+func (k groupKind[T]) all(ctx context.Context, c *Client, bar Bar) ([]T, error) {
+	roadmapID := hrefID(bar.BarLinks.Roadmap["href"])
+	if roadmapID == 0 {
+		return nil, fmt.Errorf("productplan: bar %v has no roadmap link", bar.ID)
+	}
+
+	return ListAll(k.iter(ctx, c, Roadmap{ID: roadmapID}, nil), 0)
+}
+
+// resolve finds the group named in fields among groups, or returns false.
+func (k groupKind[T]) resolve(fields map[string]string, groups []T) (T, bool) {
+	name := fields[k.field]
+	if name == "" {
+		var none T
+		return none, false
+	}
+	return findGroupByName(groups, name)
+}
+
+// move moves a bar to group, keeping its other fields.
+func (k groupKind[T]) move(ctx context.Context, s *BarsService, bar Bar, group T) (*BarsResponse, error) {
+	fields := make(map[string]string, len(bar.Fields)+1)
+	for name, value := range bar.Fields {
+		fields[name] = value
+	}
+	fields[k.field] = group.groupName()
+
+	return s.UpdateBarWithContext(ctx, bar.ID, UpdateBar{Fields: fields})
+}
+
+// moveByName moves a bar to the group of its roadmap with the given name, or returns errNotFound.
+func (k groupKind[T]) moveByName(ctx context.Context, s *BarsService, bar Bar, name string) (*BarsResponse, error) {
+	return k.moveFound(ctx, s, bar, func(groups []T) (T, bool) { return findGroupByName(groups, name) }, fmt.Sprintf("%q", name))
+}
+
+// moveByID moves a bar to the group of its roadmap with the given ID, or returns errNotFound.
+func (k groupKind[T]) moveByID(ctx context.Context, s *BarsService, bar Bar, id int) (*BarsResponse, error) {
+	return k.moveFound(ctx, s, bar, func(groups []T) (T, bool) { return findGroupByID(groups, id) }, fmt.Sprint(id))
+}
+
+// moveFound fetches the groups of the roadmap of a bar, and moves it to the one found.
+func (k groupKind[T]) moveFound(ctx context.Context, s *BarsService, bar Bar, find func(groups []T) (T, bool), key string) (*BarsResponse, error) {
+	groups, err := k.all(ctx, s.client, bar)
+	if err != nil {
+		return nil, err
+	}
+
+	group, ok := find(groups)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", k.errNotFound, key)
+	}
+	return k.move(ctx, s, bar, group)
+}
+
+// findGroup returns the first group matching, or false.
+func findGroup[T roadmapGroup](groups []T, match func(T) bool) (T, bool) {
+	for _, group := range groups {
+		if match(group) {
+			return group, true
+		}
+	}
+	var none T
+	return none, false
+}
+
+// findGroupByName returns the group with the given name, or false.
+func findGroupByName[T roadmapGroup](groups []T, name string) (T, bool) {
+	return findGroup(groups, func(group T) bool { return group.groupName() == name })
+}
+
+// findGroupByID returns the group with the given ID, or false.
+func findGroupByID[T roadmapGroup](groups []T, id int) (T, bool) {
+	return findGroup(groups, func(group T) bool { return group.groupID() == id })
+}
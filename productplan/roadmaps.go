@@ -1,6 +1,7 @@
 package productplan
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -12,50 +13,91 @@ type RoadmapsService struct {
 
 // RoadmapsResponse represents a response from an API method that returns a Roadmap struct.
 type RoadmapsResponse struct {
+	Response
 	Roadmap
 }
 
 // ListRoadmaps get a list of roadmaps
-func (s *RoadmapsService) ListRoadmaps(options *RoadmapListOptions) (*[]RoadmapsResponse, error) {
-	path := "/api/roadmaps"
-	var roadmapsResponse *[]RoadmapsResponse
+func (s *RoadmapsService) ListRoadmaps(options *RoadmapListOptions) (*ListResponse[Roadmap], error) {
+	return s.ListRoadmapsWithContext(context.Background(), options)
+}
 
-	path, err := addURLQueryOptions(path, options)
-	if err != nil {
-		return nil, err
-	}
+// ListRoadmapsWithContext get a page of roadmaps using the given context.
+// Use options.Cursor to select the page to return.
+func (s *RoadmapsService) ListRoadmapsWithContext(ctx context.Context, options *RoadmapListOptions) (*ListResponse[Roadmap], error) {
+	return getList[Roadmap](ctx, s.client, "/api/roadmaps", options)
+}
 
-	_, err = s.client.get(path, &roadmapsResponse)
-	if err != nil {
-		return nil, err
-	}
+// IterRoadmaps iterates over all the roadmaps, fetching the pages as needed.
+func (s *RoadmapsService) IterRoadmaps(ctx context.Context, options *RoadmapListOptions) *Iterator[Roadmap] {
+	return newIterator(ctx, func(ctx context.Context, cursor *Cursor) (*ListResponse[Roadmap], error) {
+		pageOptions := RoadmapListOptions{}
+		if options != nil {
+			pageOptions = *options
+		}
+		pageOptions.ListOptions = *withCursor(&pageOptions.ListOptions, cursor)
 
-	return roadmapsResponse, nil
+		return s.ListRoadmapsWithContext(ctx, &pageOptions)
+	})
 }
 
 // GetRoadmap roadmap by ID
 func (s *RoadmapsService) GetRoadmap(id int) (*RoadmapsResponse, error) {
+	return s.GetRoadmapWithContext(context.Background(), id)
+}
+
+// GetRoadmapWithContext roadmap by ID using the given context
+func (s *RoadmapsService) GetRoadmapWithContext(ctx context.Context, id int) (*RoadmapsResponse, error) {
 	path := fmt.Sprintf("/api/roadmaps/%v", id)
-	var roadmapsResponse *RoadmapsResponse
+	roadmapsResponse := &RoadmapsResponse{}
 
-	_, err := s.client.get(path, &roadmapsResponse)
+	resp, err := s.client.get(ctx, path, roadmapsResponse)
 	if err != nil {
 		return nil, err
 	}
 
+	roadmapsResponse.Response = newResponse(resp)
 	return roadmapsResponse, nil
 }
 
 // GetBars get bars on a roadmap
-func (s *RoadmapsService) GetBars(roadmap Roadmap) (*[]BarsResponse, error) {
+func (s *RoadmapsService) GetBars(roadmap Roadmap) (*ListResponse[Bar], error) {
+	return s.GetBarsWithContext(context.Background(), roadmap, nil)
+}
+
+// GetBarsWithContext get a page of bars on a roadmap using the given context.
+// Use options.Cursor to select the page to return.
+func (s *RoadmapsService) GetBarsWithContext(ctx context.Context, roadmap Roadmap, options *ListOptions) (*ListResponse[Bar], error) {
 	path := fmt.Sprintf("/api/roadmaps/%v/bars", roadmap.ID)
-	// https://github.com/DaveAppleton/LoadObjectSliceFromJson
-	var barsResponse *[]BarsResponse
+	return getList[Bar](ctx, s.client, path, options)
+}
 
-	_, err := s.client.get(path, &barsResponse)
-	if err != nil {
-		return nil, err
+// IterBars iterates over all the bars on a roadmap, fetching the pages as needed.
+func (s *RoadmapsService) IterBars(ctx context.Context, roadmap Roadmap, options *ListOptions) *Iterator[Bar] {
+	return newIterator(ctx, func(ctx context.Context, cursor *Cursor) (*ListResponse[Bar], error) {
+		return s.GetBarsWithContext(ctx, roadmap, withCursor(options, cursor))
+	})
+}
+
+// GetIdeas get ideas on a roadmap
+func (s *RoadmapsService) GetIdeas(roadmap Roadmap) (*ListResponse[Ideas], error) {
+	return s.GetIdeasWithContext(context.Background(), roadmap, nil)
+}
+
+// GetIdeasWithContext get a page of ideas on a roadmap using the given context,
+// following the ideas link of the roadmap when available.
+// Use options.Cursor to select the page to return.
+func (s *RoadmapsService) GetIdeasWithContext(ctx context.Context, roadmap Roadmap, options *ListOptions) (*ListResponse[Ideas], error) {
+	path := roadmap.RoadmapLinks.Ideas["href"]
+	if path == "" {
+		path = fmt.Sprintf("/api/roadmaps/%v/ideas", roadmap.ID)
 	}
+	return getList[Ideas](ctx, s.client, path, options)
+}
 
-	return barsResponse, nil
+// IterIdeas iterates over all the ideas on a roadmap, fetching the pages as needed.
+func (s *RoadmapsService) IterIdeas(ctx context.Context, roadmap Roadmap, options *ListOptions) *Iterator[Ideas] {
+	return newIterator(ctx, func(ctx context.Context, cursor *Cursor) (*ListResponse[Ideas], error) {
+		return s.GetIdeasWithContext(ctx, roadmap, withCursor(options, cursor))
+	})
 }
@@ -1,6 +1,7 @@
 package productplan
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -29,7 +30,7 @@ func TestRoadmapsService_ListRoadmaps_WithOptions(t *testing.T) {
 		t.Fatalf("Roadmaps.ListRoadmaps() returned error: %v", err)
 	}
 
-	roadmap0 := (*roadmapsResponse)[0]
+	roadmap0 := roadmapsResponse.Items[0]
 
 	d := []time.Duration{time.Second}
 	timestamps := Timestamps{CreatedAt: time.Date(2017, 10, 03, 12, 58, 07, 07, time.Local).Round(d[0]),
@@ -50,13 +51,13 @@ func TestRoadmapsService_ListRoadmaps_WithOptions(t *testing.T) {
 		RoadmapLinks: roadmapLinks,
 	}
 
-	got0 := roadmap0.Roadmap
+	got0 := roadmap0
 	if !reflect.DeepEqual(got0, want0) {
 		t.Errorf("roadmap.Roadmap returned GOT: %+v, WANT %+v", got0, want0)
 	}
 
 	// TODO: CHANGE roadmap ID
-	roadmap1 := (*roadmapsResponse)[1]
+	roadmap1 := roadmapsResponse.Items[1]
 	want1 := Roadmap{
 		Href:         "/api/roadmaps/7302",
 		ID:           7302,
@@ -67,7 +68,7 @@ func TestRoadmapsService_ListRoadmaps_WithOptions(t *testing.T) {
 		RoadmapLinks: roadmapLinks,
 	}
 
-	got1 := roadmap1.Roadmap
+	got1 := roadmap1
 	if !reflect.DeepEqual(got1, want1) {
 		t.Errorf("roadmap.Roadmap returned GOT: %+v, WANT %+v", got1, want1)
 	}
@@ -95,8 +96,8 @@ func TestRoadmapsService_ListRoadmaps_WithOptions_NotFound(t *testing.T) {
 		t.Fatalf("Roadmaps.ListRoadmaps() returned error: %v", err)
 	}
 
-	got := *roadmapsResponse
-	want := []RoadmapsResponse{}
+	got := roadmapsResponse.Items
+	want := []Roadmap{}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("Roadmaps.ListRoadmaps returned GOT: %+v, WANT %+v", got, want)
 	}
@@ -122,7 +123,7 @@ func TestRoadmapsService_ListRoadmaps(t *testing.T) {
 		t.Fatalf("Roadmaps.ListRoadmaps() returned error: %v", err)
 	}
 
-	for _, roadmap := range *roadmapsResponse {
+	for _, roadmap := range roadmapsResponse.Items {
 		fmt.Println(roadmap.Name)
 	}
 
@@ -232,11 +233,73 @@ func TestRoadmapsService_GetBars(t *testing.T) {
 		BarLinks:       barLinks,
 	}
 
-	bar0 := (*roadmapsResponse)[0]
-	got0 := bar0.Bar
+	bar0 := roadmapsResponse.Items[0]
+	got0 := bar0
 
 	if !reflect.DeepEqual(got0, want0) {
 		t.Errorf("Roadmaps.GetBars returned GOT: %+v, WANT %+v", got0, want0)
 	}
 
 }
+
+func TestRoadmapsService_ListRoadmaps_Pagination(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/roadmaps", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/roadmaps/list_roadmaps_success.http")
+
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+
+		if got, want := r.URL.Query().Get("pagination"), "by=id,items=2"; got != want {
+			t.Errorf("Request pagination = %v, want %v", got, want)
+		}
+
+		w.Header().Set("Link", httpResponse.Header.Get("Link"))
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	options := &RoadmapListOptions{ListOptions: ListOptions{Cursor: &Cursor{By: "id", Items: 2}}}
+	page, err := client.Roadmaps.ListRoadmapsWithContext(context.Background(), options)
+	if err != nil {
+		t.Fatalf("Roadmaps.ListRoadmapsWithContext() returned error: %v", err)
+	}
+
+	if len(page.Items) != 2 {
+		t.Errorf("Roadmaps.ListRoadmapsWithContext() returned %v roadmaps, want %v", len(page.Items), 2)
+	}
+
+	wantNext := &Cursor{By: "id", From: "7302", Items: 500}
+	if got := page.Pagination.Next; !reflect.DeepEqual(got, wantNext) {
+		t.Errorf("page.Pagination.Next returned %+v, want %+v", got, wantNext)
+	}
+
+	wantFirst := &Cursor{By: "id", Items: 500}
+	if got := page.Pagination.First; !reflect.DeepEqual(got, wantFirst) {
+		t.Errorf("page.Pagination.First returned %+v, want %+v", got, wantFirst)
+	}
+}
+
+func TestRoadmapsService_ListRoadmaps_Pagination_LastPage(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/api/roadmaps", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/roadmaps/list_roadmaps_not_found.http")
+
+		w.Header().Set("Link", httpResponse.Header.Get("Link"))
+		w.WriteHeader(httpResponse.StatusCode)
+		io.Copy(w, httpResponse.Body)
+	})
+
+	page, err := client.Roadmaps.ListRoadmapsWithContext(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Roadmaps.ListRoadmapsWithContext() returned error: %v", err)
+	}
+
+	if page.Pagination.Next != nil {
+		t.Errorf("page.Pagination.Next returned %+v, want nil", page.Pagination.Next)
+	}
+}
@@ -1,5 +1,7 @@
 package productplan
 
+import "context"
+
 // StatusService handles communication with the status
 // methods of the Productplan API.
 type StatusService struct {
@@ -27,14 +29,19 @@ type StatusResponse struct {
 
 // GetStatus get API status
 func (s *StatusService) GetStatus() (*StatusResponse, error) {
+	return s.GetStatusWithContext(context.Background())
+}
+
+// GetStatusWithContext get API status using the given context
+func (s *StatusService) GetStatusWithContext(ctx context.Context) (*StatusResponse, error) {
 	path := "/api/status"
 	statusResponse := &StatusResponse{}
 
-	resp, err := s.client.get(path, statusResponse)
+	resp, err := s.client.get(ctx, path, statusResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	statusResponse.HTTPResponse = resp
+	statusResponse.Response = newResponse(resp)
 	return statusResponse, nil
 }